@@ -48,7 +48,7 @@ const (
 // +devfile:getter:generate
 type CommandGroup struct {
 	// Kind of group the command is part of
-	Kind CommandGroupKind `json:"kind"`
+	Kind CommandGroupKind `json:"kind" variables:"-"`
 
 	// +optional
 	// Identifies the default command for a given group kind
@@ -77,7 +77,7 @@ type Command struct {
 	// a parent, or in events.
 	// +kubebuilder:validation:Pattern=^[a-z0-9]([-a-z0-9]*[a-z0-9])?$
 	// +kubebuilder:validation:MaxLength=63
-	Id string `json:"id"`
+	Id string `json:"id" variables:"-"`
 	// Map of implementation-dependant free-form YAML attributes.
 	// +optional
 	// +kubebuilder:validation:Type=object
@@ -92,7 +92,7 @@ type CommandUnion struct {
 	// Type of devworkspace command
 	// +unionDiscriminator
 	// +optional
-	CommandType CommandType `json:"commandType,omitempty"`
+	CommandType CommandType `json:"commandType,omitempty" variables:"-"`
 
 	// CLI Command executed in an existing component container
 	// +optional
@@ -140,7 +140,7 @@ type ExecCommand struct {
 
 	// Describes component to which given action relates
 	//
-	Component string `json:"component"`
+	Component string `json:"component" variables:"-"`
 
 	// Working directory where the command should be executed
 	//
@@ -176,7 +176,7 @@ type ApplyCommand struct {
 
 	// Describes component that will be applied
 	//
-	Component string `json:"component"`
+	Component string `json:"component" variables:"-"`
 }
 
 // +devfile:getter:generate
@@ -184,7 +184,7 @@ type CompositeCommand struct {
 	LabeledCommand `json:",inline"`
 
 	// The commands that comprise this composite command
-	Commands []string `json:"commands,omitempty" patchStrategy:"replace"`
+	Commands []string `json:"commands,omitempty" patchStrategy:"replace" variables:"-"`
 
 	// Indicates if the sub-commands should be executed concurrently
 	// +optional
@@ -197,7 +197,7 @@ type CustomCommand struct {
 
 	// Class of command that the associated implementation component
 	// should use to process this command with the appropriate logic
-	CommandClass string `json:"commandClass"`
+	CommandClass string `json:"commandClass" variables:"-"`
 
 	// Additional free-form configuration for this custom command
 	// that the implementation component will know how to use
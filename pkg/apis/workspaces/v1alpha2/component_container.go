@@ -126,7 +126,7 @@ type VolumeMount struct {
 	// then they will reuse the same volume and will be able to access to the same files.
 	// +kubebuilder:validation:Pattern=^[a-z0-9]([-a-z0-9]*[a-z0-9])?$
 	// +kubebuilder:validation:MaxLength=63
-	Name string `json:"name"`
+	Name string `json:"name" variables:"-"`
 
 	// The path in the component container where the volume should be mounted.
 	// If not path is mentioned, default path is the is `/<name>`.
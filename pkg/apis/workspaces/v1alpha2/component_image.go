@@ -47,7 +47,7 @@ type ImageUnion struct {
 	//
 	// +unionDiscriminator
 	// +optional
-	ImageType ImageType `json:"imageType,omitempty"`
+	ImageType ImageType `json:"imageType,omitempty" variables:"-"`
 
 	// Allows specifying dockerfile type build
 	// +optional
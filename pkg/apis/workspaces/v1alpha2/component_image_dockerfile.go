@@ -41,7 +41,7 @@ type DockerfileSrc struct {
 	// +
 	// +unionDiscriminator
 	// +optional
-	SrcType DockerfileSrcType `json:"srcType,omitempty"`
+	SrcType DockerfileSrcType `json:"srcType,omitempty" variables:"-"`
 
 	// URI Reference of a Dockerfile.
 	// It can be a full URL or a relative URI from the current devfile as the base URI.
@@ -33,7 +33,7 @@ type K8sLikeComponentLocation struct {
 	// +
 	// +unionDiscriminator
 	// +optional
-	LocationType K8sLikeComponentLocationType `json:"locationType,omitempty"`
+	LocationType K8sLikeComponentLocationType `json:"locationType,omitempty" variables:"-"`
 
 	// Location in a file fetched from a uri.
 	// +optional
@@ -18,6 +18,6 @@ package v1alpha2
 
 type PluginComponent struct {
 	BaseComponent   `json:",inline"`
-	ImportReference `json:",inline"`
+	ImportReference `json:",inline" variables:"-"`
 	PluginOverrides `json:",inline"`
 }
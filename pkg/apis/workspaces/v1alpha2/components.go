@@ -48,7 +48,7 @@ type Component struct {
 	// devfile that may reference this component through a parent or a plugin.
 	// +kubebuilder:validation:Pattern=^[a-z0-9]([-a-z0-9]*[a-z0-9])?$
 	// +kubebuilder:validation:MaxLength=63
-	Name string `json:"name"`
+	Name string `json:"name" variables:"-"`
 	// Map of implementation-dependant free-form YAML attributes.
 	// +optional
 	// +kubebuilder:validation:Type=object
@@ -64,7 +64,7 @@ type ComponentUnion struct {
 	//
 	// +unionDiscriminator
 	// +optional
-	ComponentType ComponentType `json:"componentType,omitempty"`
+	ComponentType ComponentType `json:"componentType,omitempty" variables:"-"`
 
 	// Allows adding and configuring devworkspace-related containers
 	// +optional
@@ -114,7 +114,7 @@ type ComponentUnion struct {
 type CustomComponent struct {
 	// Class of component that the associated implementation controller
 	// should use to process this command with the appropriate logic
-	ComponentClass string `json:"componentClass"`
+	ComponentClass string `json:"componentClass" variables:"-"`
 
 	// Additional free-form configuration for this custom component
 	// that the implementation controller will know how to use
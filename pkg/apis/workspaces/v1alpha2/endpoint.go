@@ -64,7 +64,7 @@ const (
 type Endpoint struct {
 	// +kubebuilder:validation:Pattern=^[a-z0-9]([-a-z0-9]*[a-z0-9])?$
 	// +kubebuilder:validation:MaxLength=63
-	Name string `json:"name"`
+	Name string `json:"name" variables:"-"`
 
 	// Port number to be used within the container component. The same port cannot
 	// be used by two different container components.
@@ -85,7 +85,7 @@ type Endpoint struct {
 	// Default value is `public`
 	// +optional
 	// +kubebuilder:default=public
-	Exposure EndpointExposure `json:"exposure,omitempty"`
+	Exposure EndpointExposure `json:"exposure,omitempty" variables:"-"`
 
 	// Describes the application and transport protocols of the traffic that will go through this endpoint.
 	//
@@ -106,7 +106,7 @@ type Endpoint struct {
 	// Default value is `http`
 	// +optional
 	// +kubebuilder:default=http
-	Protocol EndpointProtocol `json:"protocol,omitempty"`
+	Protocol EndpointProtocol `json:"protocol,omitempty" variables:"-"`
 
 	// Describes whether the endpoint should be secured and protected by some
 	// authentication process. This requires a protocol of `https` or `wss`.
@@ -35,7 +35,7 @@ type ImportReferenceUnion struct {
 	// +
 	// +unionDiscriminator
 	// +optional
-	ImportReferenceType ImportReferenceType `json:"importReferenceType,omitempty"`
+	ImportReferenceType ImportReferenceType `json:"importReferenceType,omitempty" variables:"-"`
 
 	// URI Reference of a parent devfile YAML file.
 	// It can be a full URL or a relative URI with the current devfile as the base URI.
@@ -25,7 +25,7 @@ type Project struct {
 	// Project name
 	// +kubebuilder:validation:Pattern=^[a-z0-9]([-a-z0-9]*[a-z0-9])?$
 	// +kubebuilder:validation:MaxLength=63
-	Name string `json:"name"`
+	Name string `json:"name" variables:"-"`
 
 	// Map of implementation-dependant free-form YAML attributes.
 	// +optional
@@ -45,7 +45,7 @@ type StarterProject struct {
 	// Project name
 	// +kubebuilder:validation:Pattern=^[a-z0-9]([-a-z0-9]*[a-z0-9])?$
 	// +kubebuilder:validation:MaxLength=63
-	Name string `json:"name"`
+	Name string `json:"name" variables:"-"`
 
 	// Map of implementation-dependant free-form YAML attributes.
 	// +optional
@@ -84,7 +84,7 @@ type ProjectSource struct {
 	// +
 	// +unionDiscriminator
 	// +optional
-	SourceType ProjectSourceType `json:"sourceType,omitempty"`
+	SourceType ProjectSourceType `json:"sourceType,omitempty" variables:"-"`
 
 	// Project's Git source
 	// +optional
@@ -104,7 +104,7 @@ type CommonProjectSource struct {
 }
 
 type CustomProjectSource struct {
-	ProjectSourceClass string `json:"projectSourceClass"`
+	ProjectSourceClass string `json:"projectSourceClass" variables:"-"`
 	// +kubebuilder:pruning:PreserveUnknownFields
 	// +kubebuilder:validation:EmbeddedResource
 	EmbeddedResource runtime.RawExtension `json:"embeddedResource"`
@@ -72,7 +72,7 @@ type ComponentParentOverride struct {
 	// devfile that may reference this component through a parent or a plugin.
 	// +kubebuilder:validation:Pattern=^[a-z0-9]([-a-z0-9]*[a-z0-9])?$
 	// +kubebuilder:validation:MaxLength=63
-	Name string `json:"name"`
+	Name string `json:"name" variables:"-"`
 
 	// Map of implementation-dependant free-form YAML attributes.
 	// +optional
@@ -88,7 +88,7 @@ type ProjectParentOverride struct {
 	// Project name
 	// +kubebuilder:validation:Pattern=^[a-z0-9]([-a-z0-9]*[a-z0-9])?$
 	// +kubebuilder:validation:MaxLength=63
-	Name string `json:"name"`
+	Name string `json:"name" variables:"-"`
 
 	// Map of implementation-dependant free-form YAML attributes.
 	// +optional
@@ -109,7 +109,7 @@ type StarterProjectParentOverride struct {
 	// Project name
 	// +kubebuilder:validation:Pattern=^[a-z0-9]([-a-z0-9]*[a-z0-9])?$
 	// +kubebuilder:validation:MaxLength=63
-	Name string `json:"name"`
+	Name string `json:"name" variables:"-"`
 
 	// Map of implementation-dependant free-form YAML attributes.
 	// +optional
@@ -136,7 +136,7 @@ type CommandParentOverride struct {
 	// a parent, or in events.
 	// +kubebuilder:validation:Pattern=^[a-z0-9]([-a-z0-9]*[a-z0-9])?$
 	// +kubebuilder:validation:MaxLength=63
-	Id string `json:"id"`
+	Id string `json:"id" variables:"-"`
 
 	// Map of implementation-dependant free-form YAML attributes.
 	// +optional
@@ -155,7 +155,7 @@ type ComponentUnionParentOverride struct {
 	//
 	// +unionDiscriminator
 	// +optional
-	ComponentType ComponentTypeParentOverride `json:"componentType,omitempty"`
+	ComponentType ComponentTypeParentOverride `json:"componentType,omitempty" variables:"-"`
 
 	// Allows adding and configuring devworkspace-related containers
 	// +optional
@@ -203,7 +203,7 @@ type ProjectSourceParentOverride struct {
 	// +
 	// +unionDiscriminator
 	// +optional
-	SourceType ProjectSourceTypeParentOverride `json:"sourceType,omitempty"`
+	SourceType ProjectSourceTypeParentOverride `json:"sourceType,omitempty" variables:"-"`
 
 	// Project's Git source
 	// +optional
@@ -221,7 +221,7 @@ type CommandUnionParentOverride struct {
 	// Type of devworkspace command
 	// +unionDiscriminator
 	// +optional
-	CommandType CommandTypeParentOverride `json:"commandType,omitempty"`
+	CommandType CommandTypeParentOverride `json:"commandType,omitempty" variables:"-"`
 
 	// CLI Command executed in an existing component container
 	// +optional
@@ -282,7 +282,7 @@ type ImageComponentParentOverride struct {
 
 type PluginComponentParentOverride struct {
 	BaseComponentParentOverride   `json:",inline"`
-	ImportReferenceParentOverride `json:",inline"`
+	ImportReferenceParentOverride `json:",inline" variables:"-"`
 	PluginOverridesParentOverride `json:",inline"`
 }
 
@@ -324,7 +324,7 @@ type ExecCommandParentOverride struct {
 	//  +optional
 	// Describes component to which given action relates
 	//
-	Component string `json:"component,omitempty"`
+	Component string `json:"component,omitempty" variables:"-"`
 
 	// Working directory where the command should be executed
 	//
@@ -360,14 +360,14 @@ type ApplyCommandParentOverride struct {
 	//  +optional
 	// Describes component that will be applied
 	//
-	Component string `json:"component,omitempty"`
+	Component string `json:"component,omitempty" variables:"-"`
 }
 
 type CompositeCommandParentOverride struct {
 	LabeledCommandParentOverride `json:",inline"`
 
 	// The commands that comprise this composite command
-	Commands []string `json:"commands,omitempty" patchStrategy:"replace"`
+	Commands []string `json:"commands,omitempty" patchStrategy:"replace" variables:"-"`
 
 	// Indicates if the sub-commands should be executed concurrently
 	// +optional
@@ -452,7 +452,7 @@ type EndpointParentOverride struct {
 
 	// +kubebuilder:validation:Pattern=^[a-z0-9]([-a-z0-9]*[a-z0-9])?$
 	// +kubebuilder:validation:MaxLength=63
-	Name string `json:"name"`
+	Name string `json:"name" variables:"-"`
 
 	//  +optional
 	// Port number to be used within the container component. The same port cannot
@@ -473,7 +473,7 @@ type EndpointParentOverride struct {
 	//
 	// Default value is `public`
 	// +optional
-	Exposure EndpointExposureParentOverride `json:"exposure,omitempty"`
+	Exposure EndpointExposureParentOverride `json:"exposure,omitempty" variables:"-"`
 
 	// Describes the application and transport protocols of the traffic that will go through this endpoint.
 	//
@@ -493,7 +493,7 @@ type EndpointParentOverride struct {
 	//
 	// Default value is `http`
 	// +optional
-	Protocol EndpointProtocolParentOverride `json:"protocol,omitempty"`
+	Protocol EndpointProtocolParentOverride `json:"protocol,omitempty" variables:"-"`
 
 	// Describes whether the endpoint should be secured and protected by some
 	// authentication process. This requires a protocol of `https` or `wss`.
@@ -645,7 +645,7 @@ type VolumeMountParentOverride struct {
 	// then they will reuse the same volume and will be able to access to the same files.
 	// +kubebuilder:validation:Pattern=^[a-z0-9]([-a-z0-9]*[a-z0-9])?$
 	// +kubebuilder:validation:MaxLength=63
-	Name string `json:"name"`
+	Name string `json:"name" variables:"-"`
 
 	// The path in the component container where the volume should be mounted.
 	// If not path is mentioned, default path is the is `/<name>`.
@@ -671,7 +671,7 @@ type K8sLikeComponentLocationParentOverride struct {
 	// +
 	// +unionDiscriminator
 	// +optional
-	LocationType K8sLikeComponentLocationTypeParentOverride `json:"locationType,omitempty"`
+	LocationType K8sLikeComponentLocationTypeParentOverride `json:"locationType,omitempty" variables:"-"`
 
 	// Location in a file fetched from a uri.
 	// +optional
@@ -690,7 +690,7 @@ type ImageUnionParentOverride struct {
 	//
 	// +unionDiscriminator
 	// +optional
-	ImageType ImageTypeParentOverride `json:"imageType,omitempty"`
+	ImageType ImageTypeParentOverride `json:"imageType,omitempty" variables:"-"`
 
 	// Allows specifying dockerfile type build
 	// +optional
@@ -712,7 +712,7 @@ type ImportReferenceUnionParentOverride struct {
 	// +
 	// +unionDiscriminator
 	// +optional
-	ImportReferenceType ImportReferenceTypeParentOverride `json:"importReferenceType,omitempty"`
+	ImportReferenceType ImportReferenceTypeParentOverride `json:"importReferenceType,omitempty" variables:"-"`
 
 	// URI Reference of a parent devfile YAML file.
 	// It can be a full URL or a relative URI with the current devfile as the base URI.
@@ -740,7 +740,7 @@ type ComponentPluginOverrideParentOverride struct {
 	// devfile that may reference this component through a parent or a plugin.
 	// +kubebuilder:validation:Pattern=^[a-z0-9]([-a-z0-9]*[a-z0-9])?$
 	// +kubebuilder:validation:MaxLength=63
-	Name string `json:"name"`
+	Name string `json:"name" variables:"-"`
 
 	// Map of implementation-dependant free-form YAML attributes.
 	// +optional
@@ -758,7 +758,7 @@ type CommandPluginOverrideParentOverride struct {
 	// a parent, or in events.
 	// +kubebuilder:validation:Pattern=^[a-z0-9]([-a-z0-9]*[a-z0-9])?$
 	// +kubebuilder:validation:MaxLength=63
-	Id string `json:"id"`
+	Id string `json:"id" variables:"-"`
 
 	// Map of implementation-dependant free-form YAML attributes.
 	// +optional
@@ -825,7 +825,7 @@ type ComponentUnionPluginOverrideParentOverride struct {
 	//
 	// +unionDiscriminator
 	// +optional
-	ComponentType ComponentTypePluginOverrideParentOverride `json:"componentType,omitempty"`
+	ComponentType ComponentTypePluginOverrideParentOverride `json:"componentType,omitempty" variables:"-"`
 
 	// Allows adding and configuring devworkspace-related containers
 	// +optional
@@ -862,7 +862,7 @@ type CommandUnionPluginOverrideParentOverride struct {
 	// Type of devworkspace command
 	// +unionDiscriminator
 	// +optional
-	CommandType CommandTypePluginOverrideParentOverride `json:"commandType,omitempty"`
+	CommandType CommandTypePluginOverrideParentOverride `json:"commandType,omitempty" variables:"-"`
 
 	// CLI Command executed in an existing component container
 	// +optional
@@ -892,7 +892,7 @@ type CommandGroupParentOverride struct {
 
 	//  +optional
 	// Kind of group the command is part of
-	Kind CommandGroupKindParentOverride `json:"kind,omitempty"`
+	Kind CommandGroupKindParentOverride `json:"kind,omitempty" variables:"-"`
 
 	// +optional
 	// Identifies the default command for a given group kind
@@ -910,7 +910,7 @@ type DockerfileSrcParentOverride struct {
 	// +
 	// +unionDiscriminator
 	// +optional
-	SrcType DockerfileSrcTypeParentOverride `json:"srcType,omitempty"`
+	SrcType DockerfileSrcTypeParentOverride `json:"srcType,omitempty" variables:"-"`
 
 	// URI Reference of a Dockerfile.
 	// It can be a full URL or a relative URI from the current devfile as the base URI.
@@ -996,7 +996,7 @@ type ExecCommandPluginOverrideParentOverride struct {
 	//  +optional
 	// Describes component to which given action relates
 	//
-	Component string `json:"component,omitempty"`
+	Component string `json:"component,omitempty" variables:"-"`
 
 	// Working directory where the command should be executed
 	//
@@ -1032,14 +1032,14 @@ type ApplyCommandPluginOverrideParentOverride struct {
 	//  +optional
 	// Describes component that will be applied
 	//
-	Component string `json:"component,omitempty"`
+	Component string `json:"component,omitempty" variables:"-"`
 }
 
 type CompositeCommandPluginOverrideParentOverride struct {
 	LabeledCommandPluginOverrideParentOverride `json:",inline"`
 
 	// The commands that comprise this composite command
-	Commands []string `json:"commands,omitempty" patchStrategy:"replace"`
+	Commands []string `json:"commands,omitempty" patchStrategy:"replace" variables:"-"`
 
 	// Indicates if the sub-commands should be executed concurrently
 	// +optional
@@ -1160,7 +1160,7 @@ type EndpointPluginOverrideParentOverride struct {
 
 	// +kubebuilder:validation:Pattern=^[a-z0-9]([-a-z0-9]*[a-z0-9])?$
 	// +kubebuilder:validation:MaxLength=63
-	Name string `json:"name"`
+	Name string `json:"name" variables:"-"`
 
 	//  +optional
 	// Port number to be used within the container component. The same port cannot
@@ -1181,7 +1181,7 @@ type EndpointPluginOverrideParentOverride struct {
 	//
 	// Default value is `public`
 	// +optional
-	Exposure EndpointExposurePluginOverrideParentOverride `json:"exposure,omitempty"`
+	Exposure EndpointExposurePluginOverrideParentOverride `json:"exposure,omitempty" variables:"-"`
 
 	// Describes the application and transport protocols of the traffic that will go through this endpoint.
 	//
@@ -1201,7 +1201,7 @@ type EndpointPluginOverrideParentOverride struct {
 	//
 	// Default value is `http`
 	// +optional
-	Protocol EndpointProtocolPluginOverrideParentOverride `json:"protocol,omitempty"`
+	Protocol EndpointProtocolPluginOverrideParentOverride `json:"protocol,omitempty" variables:"-"`
 
 	// Describes whether the endpoint should be secured and protected by some
 	// authentication process. This requires a protocol of `https` or `wss`.
@@ -1300,7 +1300,7 @@ type VolumeMountPluginOverrideParentOverride struct {
 	// then they will reuse the same volume and will be able to access to the same files.
 	// +kubebuilder:validation:Pattern=^[a-z0-9]([-a-z0-9]*[a-z0-9])?$
 	// +kubebuilder:validation:MaxLength=63
-	Name string `json:"name"`
+	Name string `json:"name" variables:"-"`
 
 	// The path in the component container where the volume should be mounted.
 	// If not path is mentioned, default path is the is `/<name>`.
@@ -1326,7 +1326,7 @@ type K8sLikeComponentLocationPluginOverrideParentOverride struct {
 	// +
 	// +unionDiscriminator
 	// +optional
-	LocationType K8sLikeComponentLocationTypePluginOverrideParentOverride `json:"locationType,omitempty"`
+	LocationType K8sLikeComponentLocationTypePluginOverrideParentOverride `json:"locationType,omitempty" variables:"-"`
 
 	// Location in a file fetched from a uri.
 	// +optional
@@ -1345,7 +1345,7 @@ type ImageUnionPluginOverrideParentOverride struct {
 	//
 	// +unionDiscriminator
 	// +optional
-	ImageType ImageTypePluginOverrideParentOverride `json:"imageType,omitempty"`
+	ImageType ImageTypePluginOverrideParentOverride `json:"imageType,omitempty" variables:"-"`
 
 	// Allows specifying dockerfile type build
 	// +optional
@@ -1385,7 +1385,7 @@ type CommandGroupPluginOverrideParentOverride struct {
 
 	//  +optional
 	// Kind of group the command is part of
-	Kind CommandGroupKindPluginOverrideParentOverride `json:"kind,omitempty"`
+	Kind CommandGroupKindPluginOverrideParentOverride `json:"kind,omitempty" variables:"-"`
 
 	// +optional
 	// Identifies the default command for a given group kind
@@ -1403,7 +1403,7 @@ type DockerfileSrcPluginOverrideParentOverride struct {
 	// +
 	// +unionDiscriminator
 	// +optional
-	SrcType DockerfileSrcTypePluginOverrideParentOverride `json:"srcType,omitempty"`
+	SrcType DockerfileSrcTypePluginOverrideParentOverride `json:"srcType,omitempty" variables:"-"`
 
 	// URI Reference of a Dockerfile.
 	// It can be a full URL or a relative URI from the current devfile as the base URI.
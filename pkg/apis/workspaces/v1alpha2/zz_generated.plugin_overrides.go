@@ -33,7 +33,7 @@ type ComponentPluginOverride struct {
 	// devfile that may reference this component through a parent or a plugin.
 	// +kubebuilder:validation:Pattern=^[a-z0-9]([-a-z0-9]*[a-z0-9])?$
 	// +kubebuilder:validation:MaxLength=63
-	Name string `json:"name"`
+	Name string `json:"name" variables:"-"`
 
 	// Map of implementation-dependant free-form YAML attributes.
 	// +optional
@@ -51,7 +51,7 @@ type CommandPluginOverride struct {
 	// a parent, or in events.
 	// +kubebuilder:validation:Pattern=^[a-z0-9]([-a-z0-9]*[a-z0-9])?$
 	// +kubebuilder:validation:MaxLength=63
-	Id string `json:"id"`
+	Id string `json:"id" variables:"-"`
 
 	// Map of implementation-dependant free-form YAML attributes.
 	// +optional
@@ -70,7 +70,7 @@ type ComponentUnionPluginOverride struct {
 	//
 	// +unionDiscriminator
 	// +optional
-	ComponentType ComponentTypePluginOverride `json:"componentType,omitempty"`
+	ComponentType ComponentTypePluginOverride `json:"componentType,omitempty" variables:"-"`
 
 	// Allows adding and configuring devworkspace-related containers
 	// +optional
@@ -107,7 +107,7 @@ type CommandUnionPluginOverride struct {
 	// Type of devworkspace command
 	// +unionDiscriminator
 	// +optional
-	CommandType CommandTypePluginOverride `json:"commandType,omitempty"`
+	CommandType CommandTypePluginOverride `json:"commandType,omitempty" variables:"-"`
 
 	// CLI Command executed in an existing component container
 	// +optional
@@ -186,7 +186,7 @@ type ExecCommandPluginOverride struct {
 	//  +optional
 	// Describes component to which given action relates
 	//
-	Component string `json:"component,omitempty"`
+	Component string `json:"component,omitempty" variables:"-"`
 
 	// Working directory where the command should be executed
 	//
@@ -222,14 +222,14 @@ type ApplyCommandPluginOverride struct {
 	//  +optional
 	// Describes component that will be applied
 	//
-	Component string `json:"component,omitempty"`
+	Component string `json:"component,omitempty" variables:"-"`
 }
 
 type CompositeCommandPluginOverride struct {
 	LabeledCommandPluginOverride `json:",inline"`
 
 	// The commands that comprise this composite command
-	Commands []string `json:"commands,omitempty" patchStrategy:"replace"`
+	Commands []string `json:"commands,omitempty" patchStrategy:"replace" variables:"-"`
 
 	// Indicates if the sub-commands should be executed concurrently
 	// +optional
@@ -314,7 +314,7 @@ type EndpointPluginOverride struct {
 
 	// +kubebuilder:validation:Pattern=^[a-z0-9]([-a-z0-9]*[a-z0-9])?$
 	// +kubebuilder:validation:MaxLength=63
-	Name string `json:"name"`
+	Name string `json:"name" variables:"-"`
 
 	//  +optional
 	// Port number to be used within the container component. The same port cannot
@@ -335,7 +335,7 @@ type EndpointPluginOverride struct {
 	//
 	// Default value is `public`
 	// +optional
-	Exposure EndpointExposurePluginOverride `json:"exposure,omitempty"`
+	Exposure EndpointExposurePluginOverride `json:"exposure,omitempty" variables:"-"`
 
 	// Describes the application and transport protocols of the traffic that will go through this endpoint.
 	//
@@ -355,7 +355,7 @@ type EndpointPluginOverride struct {
 	//
 	// Default value is `http`
 	// +optional
-	Protocol EndpointProtocolPluginOverride `json:"protocol,omitempty"`
+	Protocol EndpointProtocolPluginOverride `json:"protocol,omitempty" variables:"-"`
 
 	// Describes whether the endpoint should be secured and protected by some
 	// authentication process. This requires a protocol of `https` or `wss`.
@@ -453,7 +453,7 @@ type VolumeMountPluginOverride struct {
 	// then they will reuse the same volume and will be able to access to the same files.
 	// +kubebuilder:validation:Pattern=^[a-z0-9]([-a-z0-9]*[a-z0-9])?$
 	// +kubebuilder:validation:MaxLength=63
-	Name string `json:"name"`
+	Name string `json:"name" variables:"-"`
 
 	// The path in the component container where the volume should be mounted.
 	// If not path is mentioned, default path is the is `/<name>`.
@@ -479,7 +479,7 @@ type K8sLikeComponentLocationPluginOverride struct {
 	// +
 	// +unionDiscriminator
 	// +optional
-	LocationType K8sLikeComponentLocationTypePluginOverride `json:"locationType,omitempty"`
+	LocationType K8sLikeComponentLocationTypePluginOverride `json:"locationType,omitempty" variables:"-"`
 
 	// Location in a file fetched from a uri.
 	// +optional
@@ -498,7 +498,7 @@ type ImageUnionPluginOverride struct {
 	//
 	// +unionDiscriminator
 	// +optional
-	ImageType ImageTypePluginOverride `json:"imageType,omitempty"`
+	ImageType ImageTypePluginOverride `json:"imageType,omitempty" variables:"-"`
 
 	// Allows specifying dockerfile type build
 	// +optional
@@ -538,7 +538,7 @@ type CommandGroupPluginOverride struct {
 
 	//  +optional
 	// Kind of group the command is part of
-	Kind CommandGroupKindPluginOverride `json:"kind,omitempty"`
+	Kind CommandGroupKindPluginOverride `json:"kind,omitempty" variables:"-"`
 
 	// +optional
 	// Identifies the default command for a given group kind
@@ -556,7 +556,7 @@ type DockerfileSrcPluginOverride struct {
 	// +
 	// +unionDiscriminator
 	// +optional
-	SrcType DockerfileSrcTypePluginOverride `json:"srcType,omitempty"`
+	SrcType DockerfileSrcTypePluginOverride `json:"srcType,omitempty" variables:"-"`
 
 	// URI Reference of a Dockerfile.
 	// It can be a full URL or a relative URI from the current devfile as the base URI.
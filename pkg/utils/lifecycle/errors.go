@@ -0,0 +1,76 @@
+//
+//
+// Copyright Red Hat
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lifecycle
+
+import (
+	"fmt"
+	"strings"
+
+	dw "github.com/devfile/api/v2/pkg/apis/workspaces/v1alpha2"
+)
+
+// MissingCommandError returns an error if a command referenced by an event, a group or a composite command does not exist
+type MissingCommandError struct {
+	commandId string
+	referrer  string
+}
+
+func (e *MissingCommandError) Error() string {
+	return fmt.Sprintf("the command %q referenced by the %s does not exist", e.commandId, e.referrer)
+}
+
+// MissingComponentError returns an error if the component an exec or apply command runs on does not exist
+type MissingComponentError struct {
+	commandId     string
+	componentName string
+}
+
+func (e *MissingComponentError) Error() string {
+	return fmt.Sprintf("command %s runs on the component %q, which does not exist", e.commandId, e.componentName)
+}
+
+// InvalidComponentTypeError returns an error if an exec or apply command runs on a component of a type it cannot run on
+type InvalidComponentTypeError struct {
+	commandId     string
+	commandType   dw.CommandType
+	componentName string
+	componentType dw.ComponentType
+}
+
+func (e *InvalidComponentTypeError) Error() string {
+	return fmt.Sprintf("%s command %s cannot run on the %s component %s", strings.ToLower(string(e.commandType)), e.commandId,
+		strings.ToLower(string(e.componentType)), e.componentName)
+}
+
+// CyclicCommandError returns an error if composite commands reference each other in a cycle
+type CyclicCommandError struct {
+	chain []string
+}
+
+func (e *CyclicCommandError) Error() string {
+	return fmt.Sprintf("composite commands reference each other in a cycle: %s", strings.Join(e.chain, " -> "))
+}
+
+// MultipleDefaultCommandsError returns an error if a command group has multiple default commands
+type MultipleDefaultCommandsError struct {
+	groupKind  dw.CommandGroupKind
+	commandIds []string
+}
+
+func (e *MultipleDefaultCommandsError) Error() string {
+	return fmt.Sprintf("the %s group has multiple default commands: %s", e.groupKind, strings.Join(e.commandIds, ", "))
+}
@@ -0,0 +1,328 @@
+//
+//
+// Copyright Red Hat
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lifecycle
+
+import (
+	"strings"
+
+	dw "github.com/devfile/api/v2/pkg/apis/workspaces/v1alpha2"
+	"github.com/devfile/api/v2/pkg/validation"
+	"github.com/hashicorp/go-multierror"
+)
+
+// EventType is the type of a devworkspace lifecycle event
+type EventType string
+
+const (
+	PreStartEvent  EventType = "preStart"
+	PostStartEvent EventType = "postStart"
+	PreStopEvent   EventType = "preStop"
+	PostStopEvent  EventType = "postStop"
+)
+
+// groupKinds are the command group kinds, in the order their default command plans are built
+var groupKinds = []dw.CommandGroupKind{
+	dw.BuildCommandGroupKind,
+	dw.RunCommandGroupKind,
+	dw.TestCommandGroupKind,
+	dw.DebugCommandGroupKind,
+	dw.DeployCommandGroupKind,
+}
+
+// Plan is the execution plan of the lifecycle events and of the default group commands of a devworkspace
+type Plan struct {
+	// Events are the plans of the preStart, postStart, preStop and postStop events, in this order.
+	// An event without any command has no plan
+	Events []EventPlan `json:"events,omitempty"`
+
+	// Groups are the plans of the default command of each command group, in the order build, run, test, debug and deploy.
+	// A group without a single default command has no plan
+	Groups []GroupPlan `json:"groups,omitempty"`
+
+	// Unreachable are the ids of the commands which are run neither by an event nor by a default group command,
+	// directly or through a composite command
+	Unreachable []string `json:"unreachable,omitempty"`
+}
+
+// EventPlan is the execution plan of a lifecycle event, the stages of the event commands run one after the other
+type EventPlan struct {
+	Event  EventType `json:"event"`
+	Stages []Stage   `json:"stages"`
+}
+
+// GroupPlan is the execution plan of the default command of a command group
+type GroupPlan struct {
+	Kind  dw.CommandGroupKind `json:"kind"`
+	Stage Stage               `json:"stage"`
+}
+
+// Stage is a stage of an execution plan: either a step running a single command, or the stages expanded from a composite command,
+// which run one after the other, or in parallel if the composite command is parallel
+type Stage struct {
+	// CommandId is the id of the command of the stage
+	CommandId string `json:"commandId"`
+
+	// Step is the command run by the stage, nil if the stage is expanded from a composite command
+	Step *Step `json:"step,omitempty"`
+
+	// Parallel is true if the stages expanded from the composite command run in parallel
+	Parallel bool `json:"parallel,omitempty"`
+
+	// Stages are the stages expanded from the composite command
+	Stages []Stage `json:"stages,omitempty"`
+}
+
+// Step is a command of an execution plan, resolved to the component it runs on
+type Step struct {
+	// CommandType is the type of the command, i.e. Exec, Apply or Custom
+	CommandType dw.CommandType `json:"commandType"`
+
+	// Component is the name of the component the exec or apply command runs on
+	Component string `json:"component,omitempty"`
+
+	// ComponentType is the type of the component the exec or apply command runs on
+	ComponentType dw.ComponentType `json:"componentType,omitempty"`
+
+	// CommandLine is the command line of the exec command
+	CommandLine string `json:"commandLine,omitempty"`
+
+	// WorkingDir is the working directory of the exec command
+	WorkingDir string `json:"workingDir,omitempty"`
+
+	// Chain is the chain of command ids from the root of the plan to the command, e.g. [buildAll, buildImages, buildBackend]
+	Chain []string `json:"chain"`
+}
+
+// BuildPlan builds the execution plan of the lifecycle events and of the default group commands of a flattened
+// devworkspace template spec content. The composite commands are expanded, and the exec and apply commands are resolved
+// to the component they run on.
+//
+// Returns non-nil error if a command references a missing command or component, an exec command does not run on a container
+// component, composite commands reference each other in a cycle, or a group has multiple default commands. The plan is
+// returned along with the errors, without the stages of the invalid commands.
+func BuildPlan(content *dw.DevWorkspaceTemplateSpecContent) (*Plan, error) {
+	builder := newPlanBuilder(content)
+	plan := &Plan{}
+
+	if events := content.Events; events != nil {
+		for _, event := range []struct {
+			eventType  EventType
+			commandIds []string
+		}{
+			{PreStartEvent, events.PreStart},
+			{PostStartEvent, events.PostStart},
+			{PreStopEvent, events.PreStop},
+			{PostStopEvent, events.PostStop},
+		} {
+			if len(event.commandIds) == 0 {
+				continue
+			}
+			eventPlan := EventPlan{Event: event.eventType}
+			for _, commandId := range event.commandIds {
+				if stage := builder.buildStage(commandId, nil, string(event.eventType)+" event"); stage != nil {
+					eventPlan.Stages = append(eventPlan.Stages, *stage)
+				}
+			}
+			plan.Events = append(plan.Events, eventPlan)
+		}
+	}
+
+	for _, groupKind := range groupKinds {
+		defaultCommand := builder.getDefaultCommand(groupKind)
+		if defaultCommand == "" {
+			continue
+		}
+		if stage := builder.buildStage(defaultCommand, nil, string(groupKind)+" group"); stage != nil {
+			plan.Groups = append(plan.Groups, GroupPlan{Kind: groupKind, Stage: *stage})
+		}
+	}
+
+	for _, command := range content.Commands {
+		if !builder.reached[strings.ToLower(command.Id)] {
+			plan.Unreachable = append(plan.Unreachable, command.Id)
+		}
+	}
+
+	return plan, builder.errors.ErrorOrNil()
+}
+
+// planBuilder expands the commands into plan stages, keeping track of the reached commands and of the errors
+type planBuilder struct {
+	content    *dw.DevWorkspaceTemplateSpecContent
+	commands   map[string]dw.Command
+	components map[string]dw.Component
+	reached    map[string]bool
+
+	errors *multierror.Error
+	// reported are the messages of the reported errors, a command expanded multiple times reports its errors once
+	reported map[string]bool
+}
+
+// newPlanBuilder returns a plan builder of the content, the command ids are resolved case-insensitively
+func newPlanBuilder(content *dw.DevWorkspaceTemplateSpecContent) *planBuilder {
+	builder := &planBuilder{
+		content:    content,
+		commands:   make(map[string]dw.Command, len(content.Commands)),
+		components: make(map[string]dw.Component, len(content.Components)),
+		reached:    make(map[string]bool),
+		reported:   make(map[string]bool),
+	}
+	for _, command := range content.Commands {
+		if _, exists := builder.commands[strings.ToLower(command.Id)]; !exists {
+			builder.commands[strings.ToLower(command.Id)] = command
+		}
+	}
+	for _, component := range content.Components {
+		if _, exists := builder.components[component.Name]; !exists {
+			builder.components[component.Name] = component
+		}
+	}
+	return builder
+}
+
+// addError reports the error, unless it has already been reported
+func (b *planBuilder) addError(err error) {
+	if b.reported[err.Error()] {
+		return
+	}
+	b.reported[err.Error()] = true
+	b.errors = multierror.Append(b.errors, err)
+}
+
+// buildStage expands the command into a stage, where chain is the chain of the composite commands expanded to reach the
+// command and referrer describes the root of the plan, e.g. preStart event. It returns nil if the command is invalid
+func (b *planBuilder) buildStage(commandId string, chain []string, referrer string) *Stage {
+	for _, chainCommandId := range chain {
+		if strings.EqualFold(chainCommandId, commandId) {
+			b.addError(&CyclicCommandError{chain: append(append([]string{}, chain...), commandId)})
+			return nil
+		}
+	}
+
+	command, ok := b.commands[strings.ToLower(commandId)]
+	if !ok {
+		if len(chain) > 0 {
+			referrer = chain[len(chain)-1] + " composite command"
+		}
+		b.addError(&MissingCommandError{commandId: commandId, referrer: referrer})
+		return nil
+	}
+	b.reached[strings.ToLower(commandId)] = true
+	chain = append(append([]string{}, chain...), command.Id)
+
+	switch {
+	case command.Composite != nil:
+		stage := &Stage{CommandId: command.Id, Parallel: command.Composite.Parallel != nil && *command.Composite.Parallel}
+		for _, subCommandId := range command.Composite.Commands {
+			if subStage := b.buildStage(subCommandId, chain, referrer); subStage != nil {
+				stage.Stages = append(stage.Stages, *subStage)
+			}
+		}
+		return stage
+	case command.Exec != nil:
+		step := b.resolveStep(command, dw.ExecCommandType, command.Exec.Component, chain)
+		if step == nil {
+			return nil
+		}
+		step.CommandLine = command.Exec.CommandLine
+		step.WorkingDir = command.Exec.WorkingDir
+		return &Stage{CommandId: command.Id, Step: step}
+	case command.Apply != nil:
+		step := b.resolveStep(command, dw.ApplyCommandType, command.Apply.Component, chain)
+		if step == nil {
+			return nil
+		}
+		return &Stage{CommandId: command.Id, Step: step}
+	case command.Custom != nil:
+		return &Stage{CommandId: command.Id, Step: &Step{CommandType: dw.CustomCommandType, Chain: chain}}
+	}
+	return nil
+}
+
+// resolveStep resolves the exec or apply command to the component it runs on. An exec command runs on a container component,
+// an apply command on a container, kubernetes, openshift or image component. It returns nil if the component is invalid
+func (b *planBuilder) resolveStep(command dw.Command, commandType dw.CommandType, componentName string, chain []string) *Step {
+	component, ok := b.components[componentName]
+	if !ok {
+		b.addError(&MissingComponentError{commandId: command.Id, componentName: componentName})
+		return nil
+	}
+
+	componentType := getComponentType(component)
+	switch componentType {
+	case dw.ContainerComponentType:
+	case dw.KubernetesComponentType, dw.OpenshiftComponentType, dw.ImageComponentType:
+		if commandType == dw.ApplyCommandType {
+			break
+		}
+		fallthrough
+	default:
+		b.addError(&InvalidComponentTypeError{commandId: command.Id, commandType: commandType, componentName: componentName, componentType: componentType})
+		return nil
+	}
+
+	return &Step{CommandType: commandType, Component: componentName, ComponentType: componentType, Chain: chain}
+}
+
+// getDefaultCommand returns the id of the default command of the group: the command with isDefault set to true,
+// or the only command of the group if isDefault is not set. It returns an empty id if the group has no default command
+func (b *planBuilder) getDefaultCommand(groupKind dw.CommandGroupKind) string {
+	var groupCommands, defaultCommands []string
+	var groupCommandDefault *bool
+	for _, command := range b.content.Commands {
+		group := validation.GetGroup(command)
+		if group == nil || group.Kind != groupKind {
+			continue
+		}
+		groupCommands = append(groupCommands, command.Id)
+		groupCommandDefault = group.IsDefault
+		if group.IsDefault != nil && *group.IsDefault {
+			defaultCommands = append(defaultCommands, command.Id)
+		}
+	}
+
+	switch {
+	case len(defaultCommands) == 1:
+		return defaultCommands[0]
+	case len(defaultCommands) > 1:
+		b.addError(&MultipleDefaultCommandsError{groupKind: groupKind, commandIds: defaultCommands})
+	case len(groupCommands) == 1 && groupCommandDefault == nil:
+		return groupCommands[0]
+	}
+	return ""
+}
+
+// getComponentType returns the type of the component
+func getComponentType(component dw.Component) dw.ComponentType {
+	switch {
+	case component.Container != nil:
+		return dw.ContainerComponentType
+	case component.Kubernetes != nil:
+		return dw.KubernetesComponentType
+	case component.Openshift != nil:
+		return dw.OpenshiftComponentType
+	case component.Image != nil:
+		return dw.ImageComponentType
+	case component.Volume != nil:
+		return dw.VolumeComponentType
+	case component.Plugin != nil:
+		return dw.PluginComponentType
+	case component.Custom != nil:
+		return dw.CustomComponentType
+	}
+	return ""
+}
@@ -0,0 +1,302 @@
+//
+//
+// Copyright Red Hat
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lifecycle
+
+import (
+	"testing"
+
+	dw "github.com/devfile/api/v2/pkg/apis/workspaces/v1alpha2"
+	"github.com/stretchr/testify/assert"
+)
+
+func generateContainerComponent(name string) dw.Component {
+	return dw.Component{
+		Name: name,
+		ComponentUnion: dw.ComponentUnion{
+			Container: &dw.ContainerComponent{Container: dw.Container{Image: "quay.io/devfile/universal-developer-image"}},
+		},
+	}
+}
+
+func generateKubernetesComponent(name string) dw.Component {
+	return dw.Component{
+		Name: name,
+		ComponentUnion: dw.ComponentUnion{
+			Kubernetes: &dw.KubernetesComponent{K8sLikeComponent: dw.K8sLikeComponent{
+				K8sLikeComponentLocation: dw.K8sLikeComponentLocation{Uri: "deploy/deployment.yaml"},
+			}},
+		},
+	}
+}
+
+func generateExecCommand(id, component string, group *dw.CommandGroup) dw.Command {
+	return dw.Command{
+		Id: id,
+		CommandUnion: dw.CommandUnion{
+			Exec: &dw.ExecCommand{
+				LabeledCommand: dw.LabeledCommand{BaseCommand: dw.BaseCommand{Group: group}},
+				CommandLine:    id + ".sh",
+				Component:      component,
+				WorkingDir:     "${PROJECT_SOURCE}",
+			},
+		},
+	}
+}
+
+func generateApplyCommand(id, component string, group *dw.CommandGroup) dw.Command {
+	return dw.Command{
+		Id: id,
+		CommandUnion: dw.CommandUnion{
+			Apply: &dw.ApplyCommand{
+				LabeledCommand: dw.LabeledCommand{BaseCommand: dw.BaseCommand{Group: group}},
+				Component:      component,
+			},
+		},
+	}
+}
+
+func generateCompositeCommand(id string, parallel bool, group *dw.CommandGroup, commands ...string) dw.Command {
+	return dw.Command{
+		Id: id,
+		CommandUnion: dw.CommandUnion{
+			Composite: &dw.CompositeCommand{
+				LabeledCommand: dw.LabeledCommand{BaseCommand: dw.BaseCommand{Group: group}},
+				Commands:       commands,
+				Parallel:       &parallel,
+			},
+		},
+	}
+}
+
+func generateGroup(kind dw.CommandGroupKind, isDefault *bool) *dw.CommandGroup {
+	return &dw.CommandGroup{Kind: kind, IsDefault: isDefault}
+}
+
+func generateExecStage(id, component string, chain ...string) Stage {
+	return Stage{
+		CommandId: id,
+		Step: &Step{
+			CommandType:   dw.ExecCommandType,
+			Component:     component,
+			ComponentType: dw.ContainerComponentType,
+			CommandLine:   id + ".sh",
+			WorkingDir:    "${PROJECT_SOURCE}",
+			Chain:         chain,
+		},
+	}
+}
+
+func TestBuildPlan(t *testing.T) {
+
+	trueValue := true
+	falseValue := false
+
+	components := []dw.Component{
+		generateContainerComponent("runtime"),
+		generateContainerComponent("tools"),
+		generateKubernetesComponent("deployment"),
+	}
+
+	tests := []struct {
+		name     string
+		commands []dw.Command
+		events   *dw.Events
+		wantPlan *Plan
+		wantErr  []string
+	}{
+		{
+			name: "Events and default group commands",
+			commands: []dw.Command{
+				generateExecCommand("install", "tools", nil),
+				generateExecCommand("build", "runtime", generateGroup(dw.BuildCommandGroupKind, nil)),
+				generateExecCommand("run", "runtime", generateGroup(dw.RunCommandGroupKind, &trueValue)),
+				generateExecCommand("debug", "runtime", generateGroup(dw.RunCommandGroupKind, &falseValue)),
+				generateApplyCommand("deploy", "deployment", generateGroup(dw.DeployCommandGroupKind, nil)),
+			},
+			events: &dw.Events{DevWorkspaceEvents: dw.DevWorkspaceEvents{
+				PostStart: []string{"install"},
+				PreStop:   []string{"Install"},
+			}},
+			wantPlan: &Plan{
+				Events: []EventPlan{
+					{Event: PostStartEvent, Stages: []Stage{generateExecStage("install", "tools", "install")}},
+					{Event: PreStopEvent, Stages: []Stage{generateExecStage("install", "tools", "install")}},
+				},
+				Groups: []GroupPlan{
+					{Kind: dw.BuildCommandGroupKind, Stage: generateExecStage("build", "runtime", "build")},
+					{Kind: dw.RunCommandGroupKind, Stage: generateExecStage("run", "runtime", "run")},
+					{Kind: dw.DeployCommandGroupKind, Stage: Stage{
+						CommandId: "deploy",
+						Step: &Step{
+							CommandType:   dw.ApplyCommandType,
+							Component:     "deployment",
+							ComponentType: dw.KubernetesComponentType,
+							Chain:         []string{"deploy"},
+						},
+					}},
+				},
+				Unreachable: []string{"debug"},
+			},
+		},
+		{
+			name: "Nested composite commands",
+			commands: []dw.Command{
+				generateExecCommand("compile", "runtime", nil),
+				generateExecCommand("lint", "tools", nil),
+				generateExecCommand("test", "runtime", nil),
+				generateCompositeCommand("check", true, nil, "lint", "test"),
+				generateCompositeCommand("buildAll", false, generateGroup(dw.BuildCommandGroupKind, &trueValue), "compile", "check"),
+			},
+			wantPlan: &Plan{
+				Groups: []GroupPlan{
+					{Kind: dw.BuildCommandGroupKind, Stage: Stage{
+						CommandId: "buildAll",
+						Stages: []Stage{
+							generateExecStage("compile", "runtime", "buildAll", "compile"),
+							{
+								CommandId: "check",
+								Parallel:  true,
+								Stages: []Stage{
+									generateExecStage("lint", "tools", "buildAll", "check", "lint"),
+									generateExecStage("test", "runtime", "buildAll", "check", "test"),
+								},
+							},
+						},
+					}},
+				},
+			},
+		},
+		{
+			name: "Group without a single default command",
+			commands: []dw.Command{
+				generateExecCommand("run", "runtime", generateGroup(dw.RunCommandGroupKind, nil)),
+				generateExecCommand("runDev", "runtime", generateGroup(dw.RunCommandGroupKind, nil)),
+				generateExecCommand("test", "runtime", generateGroup(dw.TestCommandGroupKind, &falseValue)),
+			},
+			wantPlan: &Plan{
+				Unreachable: []string{"run", "runDev", "test"},
+			},
+		},
+		{
+			name: "Duplicate command ids",
+			commands: []dw.Command{
+				generateExecCommand("run", "runtime", nil),
+				generateExecCommand("Run", "runtime", generateGroup(dw.RunCommandGroupKind, nil)),
+			},
+			wantPlan: &Plan{
+				Groups: []GroupPlan{
+					{Kind: dw.RunCommandGroupKind, Stage: generateExecStage("run", "runtime", "run")},
+				},
+			},
+		},
+		{
+			name: "Multiple default commands",
+			commands: []dw.Command{
+				generateExecCommand("run", "runtime", generateGroup(dw.RunCommandGroupKind, &trueValue)),
+				generateExecCommand("runDev", "runtime", generateGroup(dw.RunCommandGroupKind, &trueValue)),
+			},
+			wantPlan: &Plan{
+				Unreachable: []string{"run", "runDev"},
+			},
+			wantErr: []string{"the run group has multiple default commands: run, runDev"},
+		},
+		{
+			name: "Cyclic composite commands",
+			commands: []dw.Command{
+				generateExecCommand("compile", "runtime", nil),
+				generateCompositeCommand("buildAll", false, generateGroup(dw.BuildCommandGroupKind, nil), "compile", "buildImages"),
+				generateCompositeCommand("buildImages", false, nil, "buildall"),
+			},
+			wantPlan: &Plan{
+				Groups: []GroupPlan{
+					{Kind: dw.BuildCommandGroupKind, Stage: Stage{
+						CommandId: "buildAll",
+						Stages: []Stage{
+							generateExecStage("compile", "runtime", "buildAll", "compile"),
+							{CommandId: "buildImages"},
+						},
+					}},
+				},
+			},
+			wantErr: []string{"composite commands reference each other in a cycle: buildAll -> buildImages -> buildall"},
+		},
+		{
+			name: "Missing commands and components",
+			commands: []dw.Command{
+				generateExecCommand("install", "missing", nil),
+				generateCompositeCommand("buildAll", false, generateGroup(dw.BuildCommandGroupKind, nil), "compile"),
+			},
+			events: &dw.Events{DevWorkspaceEvents: dw.DevWorkspaceEvents{
+				PostStart: []string{"install", "init"},
+			}},
+			wantPlan: &Plan{
+				Events: []EventPlan{
+					{Event: PostStartEvent},
+				},
+				Groups: []GroupPlan{
+					{Kind: dw.BuildCommandGroupKind, Stage: Stage{CommandId: "buildAll"}},
+				},
+			},
+			wantErr: []string{
+				"command install runs on the component \"missing\", which does not exist",
+				"the command \"init\" referenced by the postStart event does not exist",
+				"the command \"compile\" referenced by the buildAll composite command does not exist",
+			},
+		},
+		{
+			name: "Invalid component types",
+			commands: []dw.Command{
+				generateExecCommand("deploy", "deployment", generateGroup(dw.DeployCommandGroupKind, nil)),
+				generateApplyCommand("build", "runtime", generateGroup(dw.BuildCommandGroupKind, nil)),
+			},
+			wantPlan: &Plan{
+				Groups: []GroupPlan{
+					{Kind: dw.BuildCommandGroupKind, Stage: Stage{
+						CommandId: "build",
+						Step: &Step{
+							CommandType:   dw.ApplyCommandType,
+							Component:     "runtime",
+							ComponentType: dw.ContainerComponentType,
+							Chain:         []string{"build"},
+						},
+					}},
+				},
+			},
+			wantErr: []string{"exec command deploy cannot run on the kubernetes component deployment"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			plan, err := BuildPlan(&dw.DevWorkspaceTemplateSpecContent{
+				Components: components,
+				Commands:   tt.commands,
+				Events:     tt.events,
+			})
+
+			assert.Equal(t, tt.wantPlan, plan, "The plans should be the same")
+			if len(tt.wantErr) == 0 {
+				assert.NoError(t, err, "Expected no error")
+				return
+			}
+			if assert.Error(t, err, "Expected an error") {
+				for _, wantErr := range tt.wantErr {
+					assert.Contains(t, err.Error(), wantErr, "Error message should contain the expected message")
+				}
+			}
+		})
+	}
+}
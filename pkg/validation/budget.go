@@ -0,0 +1,176 @@
+//
+//
+// Copyright Red Hat
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validation
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/devfile/api/v2/pkg/apis/workspaces/v1alpha2"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+// globalMemoryLimitPath is the path of the devfile global memory limit
+const globalMemoryLimitPath = "/metadata/globalMemoryLimit"
+
+// budgetResources are the resource requirements summed up per pod, in the order they are reported
+var budgetResources = []ResourceRequirementType{MemoryLimit, MemoryRequest, CpuLimit, CpuRequest}
+
+// containerResources is a resource requirement of a container component
+type containerResources struct {
+	componentName string
+	quantity      resource.Quantity
+}
+
+// podResources is the resource budget of a pod: the sum of the resource requirements of its container components
+type podResources struct {
+	pod        string
+	containers []string
+	totals     map[ResourceRequirementType]*resource.Quantity
+
+	// memoryLimits are the memory limits of the container components of the pod
+	memoryLimits []containerResources
+}
+
+// ValidateResourceBudget validates the resource budget of the container components against the devfile global memory limit
+// 1. the container components sharing the main pod should declare a memory limit (warning)
+// 2. the sum of the memory limits of the container components sharing the main pod must not exceed the global memory limit
+// 3. the resource budget of each pod is reported as an informational finding
+//
+// The resource budget is only validated if the global memory limit is set.
+func ValidateResourceBudget(globalMemoryLimit string, components []v1alpha2.Component) ValidationResult {
+	return DefaultRuleRegistry.filter(validateResourceBudget(globalMemoryLimit, components))
+}
+
+func validateResourceBudget(globalMemoryLimit string, components []v1alpha2.Component) (result ValidationResult) {
+	if globalMemoryLimit == "" {
+		return result
+	}
+
+	memoryBudget, err := resource.ParseQuantity(globalMemoryLimit)
+	if err != nil {
+		result.addError(newFinding(RuleResourceQuantity, &InvalidGlobalMemoryLimitError{globalMemoryLimit: globalMemoryLimit, errMsg: err.Error()},
+			MetadataElement, "", globalMemoryLimitPath, nil))
+		return result
+	}
+
+	for _, pod := range getPodResources(components) {
+		if pod.pod == mainPod {
+			for _, component := range components {
+				if component.Container != nil && getPod(component) == mainPod && component.Container.MemoryLimit == "" {
+					result.addWarning(newFinding(RuleMissingMemoryLimit, &MissingMemoryLimitWarning{componentName: component.Name},
+						ComponentElement, component.Name, fmt.Sprintf("%s/%s", componentTypePath(component), MemoryLimit), component.Attributes))
+				}
+			}
+
+			if pod.totals[MemoryLimit].Cmp(memoryBudget) > 0 {
+				result.addError(newFinding(RuleResourceBudget, &ResourceBudgetExceededError{
+					globalMemoryLimit: globalMemoryLimit,
+					totalMemoryLimit:  pod.totals[MemoryLimit].String(),
+					breakdown:         getMemoryLimitBreakdown(pod.memoryLimits),
+				}, MetadataElement, "", globalMemoryLimitPath, nil))
+			}
+		}
+
+		result.addInfo(newFinding(RuleResourceBreakdown, &PodResourceBudgetInfo{pod: pod.pod, containers: pod.containers, totals: getPodTotals(pod)},
+			PodElement, pod.pod, getPodPath(pod.pod, components), nil))
+	}
+
+	return result
+}
+
+// getPodResources sums up the resource requirements of the container components per pod, the main pod comes first
+// and the dedicated pods follow in the order of the components. The invalid quantities are skipped, they are reported
+// by the component validation.
+func getPodResources(components []v1alpha2.Component) []*podResources {
+	var pods []*podResources
+	podsByKey := make(map[string]*podResources)
+	for _, component := range components {
+		if component.Container == nil {
+			continue
+		}
+		key := getPod(component)
+		pod, ok := podsByKey[key]
+		if !ok {
+			pod = &podResources{pod: key, totals: make(map[ResourceRequirementType]*resource.Quantity)}
+			for _, resourceType := range budgetResources {
+				pod.totals[resourceType] = &resource.Quantity{}
+			}
+			podsByKey[key] = pod
+			if key == mainPod {
+				pods = append([]*podResources{pod}, pods...)
+			} else {
+				pods = append(pods, pod)
+			}
+		}
+		pod.containers = append(pod.containers, component.Name)
+
+		requirements := map[ResourceRequirementType]string{
+			MemoryLimit:   component.Container.MemoryLimit,
+			MemoryRequest: component.Container.MemoryRequest,
+			CpuLimit:      component.Container.CpuLimit,
+			CpuRequest:    component.Container.CpuRequest,
+		}
+		for _, resourceType := range budgetResources {
+			if requirements[resourceType] == "" {
+				continue
+			}
+			quantity, err := resource.ParseQuantity(requirements[resourceType])
+			if err != nil {
+				continue
+			}
+			pod.totals[resourceType].Add(quantity)
+			if resourceType == MemoryLimit {
+				pod.memoryLimits = append(pod.memoryLimits, containerResources{componentName: component.Name, quantity: quantity})
+			}
+		}
+	}
+	return pods
+}
+
+// getPodPath returns the path of the global memory limit for the main pod,
+// or the path of the container component with the dedicated pod
+func getPodPath(pod string, components []v1alpha2.Component) string {
+	if pod == mainPod {
+		return globalMemoryLimitPath
+	}
+	for _, component := range components {
+		if component.Name == pod {
+			return componentTypePath(component)
+		}
+	}
+	return ""
+}
+
+// getMemoryLimitBreakdown returns the memory limits of the container components, e.g. tools: 2Gi, runtime: 1Gi
+func getMemoryLimitBreakdown(memoryLimits []containerResources) string {
+	var breakdown []string
+	for _, memoryLimit := range memoryLimits {
+		breakdown = append(breakdown, fmt.Sprintf("%s: %s", memoryLimit.componentName, memoryLimit.quantity.String()))
+	}
+	return strings.Join(breakdown, ", ")
+}
+
+// getPodTotals returns the resource requirement totals of the pod, e.g. memoryLimit 3Gi, memoryRequest 1Gi, ...
+// a resource requirement not declared by any container component is reported as 0
+func getPodTotals(pod *podResources) string {
+	var totals []string
+	for _, resourceType := range budgetResources {
+		totals = append(totals, fmt.Sprintf("%s %s", resourceType, pod.totals[resourceType].String()))
+	}
+	return strings.Join(totals, ", ")
+}
@@ -0,0 +1,133 @@
+//
+//
+// Copyright Red Hat
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validation
+
+import (
+	"testing"
+
+	"github.com/devfile/api/v2/pkg/apis/workspaces/v1alpha2"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateResourceBudget(t *testing.T) {
+
+	dedicatedComponent := generateDummyContainerComponentWithResourceRequirement("dedicated", "4Gi", "2Gi", "2", "1")
+	dedicatedPod := true
+	dedicatedComponent.Container.DedicatedPod = &dedicatedPod
+
+	invalidGlobalMemoryLimitErr := "error parsing globalMemoryLimit 2GiB: .*"
+	budgetExceededErr := "the memoryLimit of the container components sharing the main pod, 3Gi \\(tools: 2Gi, runtime: 1Gi\\), exceeds the globalMemoryLimit 2Gi"
+	missingMemoryLimitWarning := "container component runtime has no memoryLimit, it is not accounted for in the globalMemoryLimit"
+	mainPodInfo := "main pod: memoryLimit 3Gi, memoryRequest 1536Mi, cpuLimit 1500m, cpuRequest 500m, for the container components tools, runtime"
+
+	tests := []struct {
+		name              string
+		globalMemoryLimit string
+		components        []v1alpha2.Component
+		wantErr           []string
+		wantWarning       []string
+		wantInfo          []string
+	}{
+		{
+			name:              "Resource budget within the global memory limit",
+			globalMemoryLimit: "4Gi",
+			components: []v1alpha2.Component{
+				generateDummyContainerComponentWithResourceRequirement("tools", "2Gi", "1Gi", "1", "250m"),
+				generateDummyContainerComponentWithResourceRequirement("runtime", "1Gi", "512Mi", "500m", "250m"),
+				generateDummyVolumeComponent("myvol", "1Gi"),
+			},
+			wantInfo: []string{mainPodInfo},
+		},
+		{
+			name: "No global memory limit",
+			components: []v1alpha2.Component{
+				generateDummyContainerComponentWithResourceRequirement("tools", "2Gi", "", "", ""),
+				generateDummyContainerComponentWithResourceRequirement("runtime", "", "", "", ""),
+			},
+		},
+		{
+			name:              "Invalid global memory limit",
+			globalMemoryLimit: "2GiB",
+			components: []v1alpha2.Component{
+				generateDummyContainerComponentWithResourceRequirement("tools", "2Gi", "", "", ""),
+			},
+			wantErr: []string{invalidGlobalMemoryLimitErr},
+		},
+		{
+			name:              "Resource budget exceeding the global memory limit",
+			globalMemoryLimit: "2Gi",
+			components: []v1alpha2.Component{
+				generateDummyContainerComponentWithResourceRequirement("tools", "2Gi", "1Gi", "1", "250m"),
+				generateDummyContainerComponentWithResourceRequirement("runtime", "1Gi", "512Mi", "500m", "250m"),
+			},
+			wantErr:  []string{budgetExceededErr},
+			wantInfo: []string{mainPodInfo},
+		},
+		{
+			name:              "Container component without memory limit",
+			globalMemoryLimit: "2Gi",
+			components: []v1alpha2.Component{
+				generateDummyContainerComponentWithResourceRequirement("tools", "2Gi", "", "", ""),
+				generateDummyContainerComponentWithResourceRequirement("runtime", "", "", "", ""),
+			},
+			wantWarning: []string{missingMemoryLimitWarning},
+			wantInfo:    []string{"main pod: memoryLimit 2Gi, memoryRequest 0, cpuLimit 0, cpuRequest 0, for the container components tools, runtime"},
+		},
+		{
+			name:              "Dedicated pod is not accounted for in the global memory limit",
+			globalMemoryLimit: "2Gi",
+			components: []v1alpha2.Component{
+				dedicatedComponent,
+				generateDummyContainerComponentWithResourceRequirement("tools", "2Gi", "1Gi", "1", "250m"),
+			},
+			wantInfo: []string{
+				"main pod: memoryLimit 2Gi, memoryRequest 1Gi, cpuLimit 1, cpuRequest 250m, for the container components tools",
+				"dedicated pod dedicated: memoryLimit 4Gi, memoryRequest 2Gi, cpuLimit 2, cpuRequest 1, for the container components dedicated",
+			},
+		},
+		{
+			name:              "Invalid quantities are not accounted for",
+			globalMemoryLimit: "2Gi",
+			components: []v1alpha2.Component{
+				generateDummyContainerComponentWithResourceRequirement("tools", "2Gi", "", "", ""),
+				generateDummyContainerComponentWithResourceRequirement("runtime", "1GiB", "", "", ""),
+			},
+			wantInfo: []string{"main pod: memoryLimit 2Gi, memoryRequest 0, cpuLimit 0, cpuRequest 0, for the container components tools, runtime"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := ValidateResourceBudget(tt.globalMemoryLimit, tt.components)
+
+			if assert.Equal(t, len(tt.wantErr), len(result.Errors), "Error list length should match") {
+				for i := 0; i < len(result.Errors); i++ {
+					assert.Regexp(t, tt.wantErr[i], result.Errors[i].Error(), "Error message should match")
+				}
+			}
+			if assert.Equal(t, len(tt.wantWarning), len(result.Warnings), "Warning list length should match") {
+				for i := 0; i < len(result.Warnings); i++ {
+					assert.Regexp(t, tt.wantWarning[i], result.Warnings[i].Error(), "Warning message should match")
+				}
+			}
+			if assert.Equal(t, len(tt.wantInfo), len(result.Info), "Info list length should match") {
+				for i := 0; i < len(result.Info); i++ {
+					assert.Equal(t, tt.wantInfo[i], result.Info[i].Error(), "Info message should match")
+				}
+			}
+		})
+	}
+}
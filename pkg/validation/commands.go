@@ -17,47 +17,92 @@
 package validation
 
 import (
+	"errors"
 	"fmt"
 	"strings"
 
 	"github.com/devfile/api/v2/pkg/apis/workspaces/v1alpha2"
-	"github.com/hashicorp/go-multierror"
 )
 
 // ValidateCommands validates the devfile commands and checks:
 // 1. there are no duplicate command ids
 // 2. the command type is not invalid
-// 3. if a command is part of a command group, there is a single default command
-func ValidateCommands(commands []v1alpha2.Command, components []v1alpha2.Component) (returnedErr error) {
+// 3. if a command is part of a command group, there is a single default command,
+// a group of multiple commands without any default command is reported as a warning
+// 4. the exec command env names are valid C identifiers, are unique and do not shadow the reserved env
+// 5. hotReloadCapable is only set on the default build, run and debug commands, reported as a warning,
+// and the deploy group has no exec command
+//
+// The custom rules of the commands scope registered in DefaultRuleRegistry are run along with the built-in checks
+func ValidateCommands(commands []v1alpha2.Command, components []v1alpha2.Component) (result ValidationResult) {
+	result = validateCommands(commands, components, nil)
+	result.Merge(DefaultRuleRegistry.run(CommandsScope, RuleContext{Commands: commands, Components: components}))
+	return DefaultRuleRegistry.filter(result)
+}
+
+// validateCommands runs the built-in checks of the commands, the checks of each command content and references
+// are looked up in the cache
+func validateCommands(commands []v1alpha2.Command, components []v1alpha2.Component, cache *validationCache) (result ValidationResult) {
 	groupKindCommandMap := make(map[v1alpha2.CommandGroupKind][]v1alpha2.Command)
+	var groupKinds []v1alpha2.CommandGroupKind
+	processedCommands := make(map[string]bool)
 	commandMap := getCommandsMap(commands)
+	// componentTypePaths are the type paths of the components by name, built on the first cache lookup
+	var componentTypePaths map[string][]string
 
-	err := v1alpha2.CheckDuplicateKeys(commands)
-	if err != nil {
-		returnedErr = multierror.Append(returnedErr, err)
-	}
+	for commandIndex, command := range commands {
+		if processedCommands[command.Id] {
+			result.addError(newFinding(RuleDuplicateKey, &DuplicateKeyError{key: command.Id},
+				CommandElement, command.Id, commandPath(command), command.Attributes))
+		}
+		processedCommands[command.Id] = true
 
-	for _, command := range commands {
-		// parentCommands is a map to keep a track of all the parent commands when validating the composite command's subcommands recursively
-		parentCommands := make(map[string]string)
-		err := validateCommand(command, parentCommands, commandMap, components)
-		if err != nil {
-			returnedErr = multierror.Append(returnedErr, resolveErrorMessageWithImportAttributes(err, command.Attributes))
+		result.Merge(cache.validate(commandCacheKind, func() (commandResult ValidationResult) {
+			// parentCommands is a map to keep a track of all the parent commands when validating the composite command's subcommands recursively
+			parentCommands := make(map[string]string)
+			err := validateCommand(command, parentCommands, commandMap, components)
+			if err != nil {
+				finding := newCommandFinding(command, err)
+				addCommandReferenceSuggestion(finding, err, commands, components)
+				commandResult.addError(finding)
+			}
+			if command.Exec != nil {
+				commandResult.Merge(validateExecCommandEnv(command))
+			}
+			return commandResult
+		}, func() []interface{} {
+			if componentTypePaths == nil {
+				componentTypePaths = getComponentTypePaths(components)
+			}
+			return getCommandCacheElements(commandIndex, command, commands, commandMap, components, componentTypePaths)
+		}))
+		if command.Exec != nil {
+			result.Merge(validateExecCommandGroup(command, commands))
 		}
 
-		commandGroup := getGroup(command)
+		commandGroup := GetGroup(command)
 		if commandGroup != nil {
+			if _, ok := groupKindCommandMap[commandGroup.Kind]; !ok {
+				groupKinds = append(groupKinds, commandGroup.Kind)
+			}
 			groupKindCommandMap[commandGroup.Kind] = append(groupKindCommandMap[commandGroup.Kind], command)
 		}
 	}
 
-	for groupKind, commands := range groupKindCommandMap {
-		if err := validateGroup(commands, groupKind); err != nil {
-			returnedErr = multierror.Append(returnedErr, err)
+	for _, groupKind := range groupKinds {
+		if err := validateGroup(groupKindCommandMap[groupKind], groupKind); err != nil {
+			finding := newGroupFinding(groupKind, err)
+			if _, ok := err.(*MissingDefaultCmdWarning); ok {
+				finding.Suggestion = fmt.Sprintf("set isDefault to true on one of the commands of the %s group", groupKind)
+				result.addWarning(finding)
+			} else {
+				finding.Suggestion = fmt.Sprintf("set isDefault to true on a single command of the %s group", groupKind)
+				result.addError(finding)
+			}
 		}
 	}
 
-	return returnedErr
+	return result
 }
 
 // validateCommand validates a given devfile command where parentCommands is a map to track all the parent commands when validating
@@ -69,6 +114,10 @@ func validateCommand(command v1alpha2.Command, parentCommands map[string]string,
 		return validateCompositeCommand(&command, parentCommands, devfileCommands, components)
 	case command.Exec != nil || command.Apply != nil:
 		return validateCommandComponent(command, components)
+	case command.Custom != nil:
+		// the custom command content is up to its command class, only its group is validated
+		// along with the other commands, and its class with the registry of the known custom classes
+		return nil
 	default:
 		return &InvalidCommandTypeError{commandId: command.Id}
 	}
@@ -83,7 +132,7 @@ func validateGroup(commands []v1alpha2.Command, groupKind v1alpha2.CommandGroupK
 	var defaultCommands []v1alpha2.Command
 	if len(commands) > 1 {
 		for _, command := range commands {
-			defaultVal := getGroup(command).IsDefault
+			defaultVal := GetGroup(command).IsDefault
 			if defaultVal != nil && *defaultVal {
 				defaultCommandCount++
 				defaultCommands = append(defaultCommands, command)
@@ -99,7 +148,7 @@ func validateGroup(commands []v1alpha2.Command, groupKind v1alpha2.CommandGroupK
 		var commandsReferenceList []string
 		for _, command := range defaultCommands {
 			commandsReferenceList = append(commandsReferenceList,
-				resolveErrorMessageWithImportAttributes(fmt.Errorf("command: %s", command.Id), command.Attributes).Error())
+				fmt.Sprintf("command: %s%s", command.Id, NewImportProvenance(command.Attributes).String()))
 		}
 		commandsReference := strings.Join(commandsReferenceList, "; ")
 		// example: there should be exactly one default command, currently there are multiple commands;
@@ -113,8 +162,66 @@ func validateGroup(commands []v1alpha2.Command, groupKind v1alpha2.CommandGroupK
 	return nil
 }
 
-// getGroup returns the group the command belongs to, or nil if the command does not belong to a group
-func getGroup(command v1alpha2.Command) *v1alpha2.CommandGroup {
+// validateExecCommandGroup checks the group semantics of the exec command:
+// 1. hotReloadCapable is only taken into account for the default build, run and debug commands, it is reported
+// as a warning on the other exec commands
+// 2. the deploy group is meant for apply commands, an exec command of the deploy group is reported as an error
+//
+// An exec command is the default command of its group if isDefault is set to true, or if it is the only command of the group
+func validateExecCommandGroup(command v1alpha2.Command, commands []v1alpha2.Command) (result ValidationResult) {
+	group := command.Exec.Group
+	execPath := commandTypePath(command)
+
+	if command.Exec.HotReloadCapable != nil && *command.Exec.HotReloadCapable {
+		var reason string
+		switch {
+		case group == nil:
+			reason = "the command does not belong to a group"
+		case group.Kind != v1alpha2.BuildCommandGroupKind && group.Kind != v1alpha2.RunCommandGroupKind && group.Kind != v1alpha2.DebugCommandGroupKind:
+			reason = fmt.Sprintf("the command belongs to the %s group", group.Kind)
+		case !isDefaultCommand(command, commands):
+			reason = fmt.Sprintf("the command is not the default command of the %s group", group.Kind)
+		}
+		if reason != "" {
+			finding := newFinding(RuleHotReloadCapable, &IgnoredHotReloadCapableWarning{commandId: command.Id, reason: reason},
+				CommandElement, command.Id, fmt.Sprintf("%s/hotReloadCapable", execPath), command.Attributes)
+			finding.Suggestion = "remove hotReloadCapable, or set the command as the default command of the build, run or debug group"
+			result.addWarning(finding)
+		}
+	}
+
+	if group != nil && group.Kind == v1alpha2.DeployCommandGroupKind {
+		finding := newFinding(RuleDeployGroup, &DeployGroupExecCommandError{commandId: command.Id},
+			CommandElement, command.Id, fmt.Sprintf("%s/group/kind", execPath), command.Attributes)
+		finding.Suggestion = "use an apply command, or a composite of apply commands, referencing an image, kubernetes or openshift component"
+		result.addError(finding)
+	}
+
+	return result
+}
+
+// isDefaultCommand returns true if the command is the default command of its group, ie; isDefault is set to true
+// or the command is the only command of the group
+func isDefaultCommand(command v1alpha2.Command, commands []v1alpha2.Command) bool {
+	group := GetGroup(command)
+	if group == nil {
+		return false
+	}
+	if group.IsDefault != nil {
+		return *group.IsDefault
+	}
+
+	groupCommandCount := 0
+	for _, devfileCommand := range commands {
+		if devfileGroup := GetGroup(devfileCommand); devfileGroup != nil && devfileGroup.Kind == group.Kind {
+			groupCommandCount++
+		}
+	}
+	return groupCommandCount == 1
+}
+
+// GetGroup returns the group the command belongs to, or nil if the command does not belong to a group
+func GetGroup(command v1alpha2.Command) *v1alpha2.CommandGroup {
 	switch {
 	case command.Composite != nil:
 		return command.Composite.Group
@@ -157,7 +264,11 @@ func validateCommandComponent(command v1alpha2.Command, components []v1alpha2.Co
 			break
 		}
 	}
-	return &InvalidCommandError{commandId: command.Id, reason: "command does not map to a valid component"}
+	commandErr := &InvalidCommandError{commandId: command.Id, reason: "command does not map to a valid component"}
+	if !hasComponent(components, commandComponent) {
+		commandErr.reference = commandComponent
+	}
+	return commandErr
 }
 
 // validateCompositeCommand checks that the specified composite command is valid. The command:
@@ -190,7 +301,7 @@ func validateCompositeCommand(command *v1alpha2.Command, parentCommands map[stri
 
 		subCommand, ok := devfileCommands[strings.ToLower(cmd)]
 		if !ok {
-			return &InvalidCommandError{commandId: command.Id, reason: fmt.Sprintf("the command %q mentioned in the composite command does not exist in the devfile", cmd)}
+			return &InvalidCommandError{commandId: command.Id, reason: fmt.Sprintf("the command %q mentioned in the composite command does not exist in the devfile", cmd), reference: cmd}
 		}
 
 		err := validateCommand(subCommand, parentCommands, devfileCommands, components)
@@ -201,3 +312,92 @@ func validateCompositeCommand(command *v1alpha2.Command, parentCommands map[stri
 	}
 	return nil
 }
+
+// hasComponent returns true if a component of the given name exists
+func hasComponent(components []v1alpha2.Component, name string) bool {
+	for _, component := range components {
+		if component.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// addCommandReferenceSuggestion suggests the closest component name or command id for the unknown reference of the
+// invalid command error. The reference can belong to a subcommand of the composite command the finding refers to, the
+// JSON patch fixes the command holding the reference
+func addCommandReferenceSuggestion(finding *Finding, err error, commands []v1alpha2.Command, components []v1alpha2.Component) {
+	var commandErr *InvalidCommandError
+	if !errors.As(err, &commandErr) || commandErr.reference == "" {
+		return
+	}
+
+	for commandIndex, command := range commands {
+		if !strings.EqualFold(command.Id, commandErr.commandId) {
+			continue
+		}
+
+		switch {
+		case command.Composite != nil:
+			var commandIds []string
+			for _, devfileCommand := range commands {
+				if !strings.EqualFold(devfileCommand.Id, command.Id) {
+					commandIds = append(commandIds, devfileCommand.Id)
+				}
+			}
+			var pointer string
+			for subCommandIndex, subCommand := range command.Composite.Commands {
+				if subCommand == commandErr.reference {
+					pointer = fmt.Sprintf("/commands/%d/composite/commands/%d", commandIndex, subCommandIndex)
+					break
+				}
+			}
+			addKeySuggestion(finding, commandErr.reference, pointer, commandIds)
+		case command.Exec != nil:
+			addKeySuggestion(finding, commandErr.reference, fmt.Sprintf("/commands/%d/exec/component", commandIndex),
+				getComponentNames(components, func(component v1alpha2.Component) bool {
+					return component.Container != nil
+				}))
+		case command.Apply != nil:
+			addKeySuggestion(finding, commandErr.reference, fmt.Sprintf("/commands/%d/apply/component", commandIndex),
+				getComponentNames(components, func(component v1alpha2.Component) bool {
+					return component.Container != nil || component.Image != nil || component.Kubernetes != nil || component.Openshift != nil
+				}))
+		}
+		return
+	}
+}
+
+// getComponentNames returns the names of the components matching the filter
+func getComponentNames(components []v1alpha2.Component, filter func(component v1alpha2.Component) bool) []string {
+	var names []string
+	for _, component := range components {
+		if filter(component) {
+			names = append(names, component.Name)
+		}
+	}
+	return names
+}
+
+// newCommandFinding returns a finding for the validation error of the given command
+func newCommandFinding(command v1alpha2.Command, err error) *Finding {
+	ruleID := RuleCommandComponent
+	switch {
+	case command.Composite != nil:
+		ruleID = RuleCompositeCommand
+	case command.Exec == nil && command.Apply == nil:
+		ruleID = RuleCommandType
+	}
+
+	return newFinding(ruleID, err, CommandElement, command.Id, commandTypePath(command), command.Attributes)
+}
+
+// newGroupFinding returns a finding for the validation error of the given command group kind
+func newGroupFinding(groupKind v1alpha2.CommandGroupKind, err error) *Finding {
+	ruleID := RuleGroupMultipleDefault
+	if _, ok := err.(*MissingDefaultCmdWarning); ok {
+		ruleID = RuleGroupMissingDefault
+	}
+
+	return newFinding(ruleID, err, CommandGroupElement, string(groupKind), fmt.Sprintf("/commands[group.kind=%s]", groupKind), nil)
+}
@@ -102,9 +102,10 @@ func TestValidateCommands(t *testing.T) {
 	invalidCmdErrWithImportAttributes := ".*command does not map to a valid component, imported from uri: http://127.0.0.1:8080, in parent overrides from main devfile"
 
 	tests := []struct {
-		name     string
-		commands []v1alpha2.Command
-		wantErr  []string
+		name        string
+		commands    []v1alpha2.Command
+		wantErr     []string
+		wantWarning []string
 	}{
 		{
 			name: "Valid Exec Command",
@@ -142,7 +143,7 @@ func TestValidateCommands(t *testing.T) {
 				generateDummyExecCommand("somecommand1", component, &v1alpha2.CommandGroup{Kind: buildGroup}),
 				generateDummyCompositeCommand("somecommand2", []string{"somecommand1"}, &v1alpha2.CommandGroup{Kind: buildGroup}),
 			},
-			wantErr: []string{noDefaultCmdErr},
+			wantWarning: []string{noDefaultCmdErr},
 		},
 		{
 			name: "Different command types belonging to the same group with more than one default",
@@ -179,7 +180,8 @@ func TestValidateCommands(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			err := ValidateCommands(tt.commands, components)
+			result := ValidateCommands(tt.commands, components)
+			err := result.Err()
 
 			if merr, ok := err.(*multierror.Error); ok && tt.wantErr != nil {
 				assert.Equal(t, len(tt.wantErr), len(merr.Errors), "Error list length should match")
@@ -189,6 +191,12 @@ func TestValidateCommands(t *testing.T) {
 			} else {
 				assert.Equal(t, nil, err, "Error should be nil")
 			}
+
+			if assert.Equal(t, len(tt.wantWarning), len(result.Warnings), "Warning list length should match") {
+				for i := 0; i < len(result.Warnings); i++ {
+					assert.Regexp(t, tt.wantWarning[i], result.Warnings[i].Error(), "Warning message should match")
+				}
+			}
 		})
 	}
 }
@@ -464,3 +472,148 @@ func TestValidateGroup(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateExecCommandGroup(t *testing.T) {
+
+	component := "alias1"
+
+	components := []v1alpha2.Component{
+		generateDummyContainerComponent(component, nil, nil, nil, v1alpha2.Annotation{}, false),
+	}
+
+	isFalse := false
+
+	// generateHotReloadCommand returns a hotReloadCapable exec command of the given group
+	generateHotReloadCommand := func(name string, group *v1alpha2.CommandGroup) v1alpha2.Command {
+		command := generateDummyExecCommand(name, component, group)
+		command.Exec.HotReloadCapable = &isTrue
+		return command
+	}
+
+	// generateCustomCommand returns a custom command of the given group
+	generateCustomCommand := func(name string, group *v1alpha2.CommandGroup) v1alpha2.Command {
+		return v1alpha2.Command{
+			Id: name,
+			CommandUnion: v1alpha2.CommandUnion{
+				Custom: &v1alpha2.CustomCommand{
+					LabeledCommand: v1alpha2.LabeledCommand{
+						BaseCommand: v1alpha2.BaseCommand{
+							Group: group,
+						},
+					},
+					CommandClass: "custom",
+				},
+			},
+		}
+	}
+
+	noGroupWarning := "hotReloadCapable of exec command run is ignored, .*: the command does not belong to a group"
+	testGroupWarning := "hotReloadCapable of exec command run is ignored, .*: the command belongs to the test group"
+	notDefaultWarning := "hotReloadCapable of exec command run2 is ignored, .*: the command is not the default command of the run group"
+	hotReloadSuggestion := "remove hotReloadCapable, or set the command as the default command of the build, run or debug group"
+	deployGroupErr := "exec command deploy cannot belong to the deploy group"
+	deployGroupSuggestion := "use an apply command, or a composite of apply commands, referencing an image, kubernetes or openshift component"
+	multipleDefaultCmdErr := "command group build error - there should be exactly one default command, currently there are multiple default commands; command: custom1; command: custom2"
+	multipleDefaultSuggestion := "set isDefault to true on a single command of the build group"
+	noDefaultCmdWarning := "command group build warning - there should be exactly one default command, currently there is no default command"
+	noDefaultSuggestion := "set isDefault to true on one of the commands of the build group"
+
+	tests := []struct {
+		name                  string
+		commands              []v1alpha2.Command
+		wantErr               []string
+		wantErrSuggestion     []string
+		wantWarning           []string
+		wantWarningSuggestion []string
+	}{
+		{
+			name: "Valid hotReloadCapable default run command",
+			commands: []v1alpha2.Command{
+				generateHotReloadCommand("run", &v1alpha2.CommandGroup{Kind: runGroup, IsDefault: &isTrue}),
+				generateDummyExecCommand("run2", component, &v1alpha2.CommandGroup{Kind: runGroup}),
+			},
+		},
+		{
+			name: "Valid hotReloadCapable single build command",
+			commands: []v1alpha2.Command{
+				generateHotReloadCommand("build", &v1alpha2.CommandGroup{Kind: buildGroup}),
+			},
+		},
+		{
+			name: "hotReloadCapable command without group",
+			commands: []v1alpha2.Command{
+				generateHotReloadCommand("run", nil),
+			},
+			wantWarning:           []string{noGroupWarning},
+			wantWarningSuggestion: []string{hotReloadSuggestion},
+		},
+		{
+			name: "hotReloadCapable test command",
+			commands: []v1alpha2.Command{
+				generateHotReloadCommand("run", &v1alpha2.CommandGroup{Kind: v1alpha2.TestCommandGroupKind}),
+			},
+			wantWarning:           []string{testGroupWarning},
+			wantWarningSuggestion: []string{hotReloadSuggestion},
+		},
+		{
+			name: "hotReloadCapable run command which is not the default",
+			commands: []v1alpha2.Command{
+				generateDummyExecCommand("run", component, &v1alpha2.CommandGroup{Kind: runGroup, IsDefault: &isTrue}),
+				generateHotReloadCommand("run2", &v1alpha2.CommandGroup{Kind: runGroup, IsDefault: &isFalse}),
+			},
+			wantWarning:           []string{notDefaultWarning},
+			wantWarningSuggestion: []string{hotReloadSuggestion},
+		},
+		{
+			name: "Exec command in the deploy group",
+			commands: []v1alpha2.Command{
+				generateDummyExecCommand("deploy", component, &v1alpha2.CommandGroup{Kind: v1alpha2.DeployCommandGroupKind}),
+			},
+			wantErr:           []string{deployGroupErr},
+			wantErrSuggestion: []string{deployGroupSuggestion},
+		},
+		{
+			name: "Valid custom command with a default group",
+			commands: []v1alpha2.Command{
+				generateCustomCommand("custom1", &v1alpha2.CommandGroup{Kind: buildGroup, IsDefault: &isTrue}),
+				generateCustomCommand("custom2", &v1alpha2.CommandGroup{Kind: buildGroup}),
+			},
+		},
+		{
+			name: "Custom commands with multiple default commands",
+			commands: []v1alpha2.Command{
+				generateCustomCommand("custom1", &v1alpha2.CommandGroup{Kind: buildGroup, IsDefault: &isTrue}),
+				generateCustomCommand("custom2", &v1alpha2.CommandGroup{Kind: buildGroup, IsDefault: &isTrue}),
+			},
+			wantErr:           []string{multipleDefaultCmdErr},
+			wantErrSuggestion: []string{multipleDefaultSuggestion},
+		},
+		{
+			name: "Custom commands without default command",
+			commands: []v1alpha2.Command{
+				generateCustomCommand("custom1", &v1alpha2.CommandGroup{Kind: buildGroup}),
+				generateCustomCommand("custom2", &v1alpha2.CommandGroup{Kind: buildGroup}),
+			},
+			wantWarning:           []string{noDefaultCmdWarning},
+			wantWarningSuggestion: []string{noDefaultSuggestion},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := ValidateCommands(tt.commands, components)
+
+			if assert.Equal(t, len(tt.wantErr), len(result.Errors), "Error list length should match") {
+				for i := 0; i < len(result.Errors); i++ {
+					assert.Regexp(t, tt.wantErr[i], result.Errors[i].Error(), "Error message should match")
+					assert.Equal(t, tt.wantErrSuggestion[i], result.Errors[i].Suggestion, "Error suggestion should match")
+				}
+			}
+			if assert.Equal(t, len(tt.wantWarning), len(result.Warnings), "Warning list length should match") {
+				for i := 0; i < len(result.Warnings); i++ {
+					assert.Regexp(t, tt.wantWarning[i], result.Warnings[i].Error(), "Warning message should match")
+					assert.Equal(t, tt.wantWarningSuggestion[i], result.Warnings[i].Suggestion, "Warning suggestion should match")
+				}
+			}
+		})
+	}
+}
@@ -18,10 +18,8 @@ package validation
 
 import (
 	"fmt"
-	"strings"
 
 	"github.com/devfile/api/v2/pkg/apis/workspaces/v1alpha2"
-	"github.com/hashicorp/go-multierror"
 	"k8s.io/apimachinery/pkg/api/resource"
 )
 
@@ -39,78 +37,51 @@ const (
 // 3. checks the URI specified in openshift components and kubernetes components are with valid format
 // 4. makes sure the component name is unique
 // 5. makes sure the image dockerfile component git src has at most one remote
-func ValidateComponents(components []v1alpha2.Component) (returnedErr error) {
+// 6. makes sure the container env names are valid C identifiers, are unique and do not customize the reserved env
+// 7. makes sure the container and image component images are valid image references
+// 8. makes sure the container volume mount paths are absolute, normalized, unique and do not collide with the sourceMapping
+// 9. reports the ephemeral volumes with a size and the volume components not mounted by any container as warnings
+// 10. parses the inlined manifest of openshift components and kubernetes components and checks it exposes the component endpoints
+// 11. checks the image dockerfile component args, build context, devfile registry source and git file location
+// 12. checks the plugin component import reference with the rules of the parent import reference
+//
+// The custom rules of the components scope registered in DefaultRuleRegistry are run along with the built-in checks
+func ValidateComponents(components []v1alpha2.Component) (result ValidationResult) {
+	result = validateComponents(components, nil)
+	result.Merge(DefaultRuleRegistry.run(ComponentsScope, RuleContext{Components: components}))
+	return DefaultRuleRegistry.filter(result)
+}
 
+// validateComponents runs the built-in checks of the components, the checks of each component content are looked up in the cache
+func validateComponents(components []v1alpha2.Component, cache *validationCache) (result ValidationResult) {
+
+	processedComponents := make(map[string]bool)
 	processedVolumes := make(map[string]bool)
-	processedVolumeMounts := make(map[string][]string)
+	var volumeMounts []volumeMountReference
+	var volumeNames []string
 	processedEndPointName := make(map[string]bool)
-	processedEndPointPort := make(map[int]bool)
-	processedComponentWithVolumeMounts := make(map[string]v1alpha2.Component)
+	processedPodEndPointPort := make(map[string]map[int]string)
 	processedDeploymentAnnotations := make(map[string]string)
 	processedServiceAnnotations := make(map[string]string)
 	deploymentAnnotationDuplication := make(map[string]bool)
 	serviceAnnotationDuplication := make(map[string]bool)
 
-	err := v1alpha2.CheckDuplicateKeys(components)
-	if err != nil {
-		returnedErr = multierror.Append(returnedErr, err)
-	}
+	for componentIndex, component := range components {
+		if processedComponents[component.Name] {
+			result.addError(newFinding(RuleDuplicateKey, &DuplicateKeyError{key: component.Name},
+				ComponentElement, component.Name, componentPath(component), component.Attributes))
+		}
+		processedComponents[component.Name] = true
+
+		result.Merge(cache.validate(componentCacheKind, func() ValidationResult {
+			return validateComponentContent(component)
+		}, func() []interface{} {
+			return []interface{}{component}
+		}))
 
-	for _, component := range components {
 		switch {
 		case component.Container != nil:
-			// Process all the volume mounts in container components to validate them later
-			for _, volumeMount := range component.Container.VolumeMounts {
-				processedVolumeMounts[component.Name] = append(processedVolumeMounts[component.Name], volumeMount.Name)
-				processedComponentWithVolumeMounts[component.Name] = component
-
-			}
-
-			// Check if any containers are customizing the reserved PROJECT_SOURCE or PROJECTS_ROOT env
-			for _, env := range component.Container.Env {
-				if env.Name == EnvProjectsSrc {
-					reservedEnvErr := &ReservedEnvError{envName: EnvProjectsSrc, componentName: component.Name}
-					returnedErr = multierror.Append(returnedErr, reservedEnvErr)
-				} else if env.Name == EnvProjectsRoot {
-					reservedEnvErr := &ReservedEnvError{envName: EnvProjectsRoot, componentName: component.Name}
-					returnedErr = multierror.Append(returnedErr, reservedEnvErr)
-				}
-			}
-			var memoryLimit, cpuLimit, memoryRequest, cpuRequest resource.Quantity
-			if component.Container.MemoryLimit != "" {
-				memoryLimit, err = resource.ParseQuantity(component.Container.MemoryLimit)
-				if err != nil {
-					parseQuantityErr := &ParsingResourceRequirementError{resource: MemoryLimit, cmpName: component.Name, errMsg: err.Error()}
-					returnedErr = multierror.Append(returnedErr, parseQuantityErr)
-				}
-			}
-			if component.Container.CpuLimit != "" {
-				cpuLimit, err = resource.ParseQuantity(component.Container.CpuLimit)
-				if err != nil {
-					parseQuantityErr := &ParsingResourceRequirementError{resource: CpuLimit, cmpName: component.Name, errMsg: err.Error()}
-					returnedErr = multierror.Append(returnedErr, parseQuantityErr)
-				}
-			}
-			if component.Container.MemoryRequest != "" {
-				memoryRequest, err = resource.ParseQuantity(component.Container.MemoryRequest)
-				if err != nil {
-					parseQuantityErr := &ParsingResourceRequirementError{resource: MemoryRequest, cmpName: component.Name, errMsg: err.Error()}
-					returnedErr = multierror.Append(returnedErr, parseQuantityErr)
-				} else if !memoryLimit.IsZero() && memoryRequest.Cmp(memoryLimit) > 0 {
-					invalidResourceRequest := &InvalidResourceRequestError{cmpName: component.Name, errMsg: fmt.Sprintf("memoryRequest is greater than memoryLimit.")}
-					returnedErr = multierror.Append(returnedErr, invalidResourceRequest)
-				}
-			}
-			if component.Container.CpuRequest != "" {
-				cpuRequest, err = resource.ParseQuantity(component.Container.CpuRequest)
-				if err != nil {
-					parseQuantityErr := &ParsingResourceRequirementError{resource: CpuRequest, cmpName: component.Name, errMsg: err.Error()}
-					returnedErr = multierror.Append(returnedErr, parseQuantityErr)
-				} else if !cpuLimit.IsZero() && cpuRequest.Cmp(cpuLimit) > 0 {
-					invalidResourceRequest := &InvalidResourceRequestError{cmpName: component.Name, errMsg: fmt.Sprintf("cpuRequest is greater than cpuLimit.")}
-					returnedErr = multierror.Append(returnedErr, invalidResourceRequest)
-				}
-			}
+			containerPath := componentTypePath(component)
 
 			// if annotation is not empty and dedicatedPod is false
 			if component.Container.Annotation != nil && component.Container.DedicatedPod != nil && !(*component.Container.DedicatedPod) {
@@ -119,7 +90,8 @@ func ValidateComponents(components []v1alpha2.Component) (returnedErr error) {
 						// only append the error for a single key once
 						if _, exist := deploymentAnnotationDuplication[key]; !exist {
 							annotationConflictErr := &AnnotationConflictError{annotationName: key, annotationType: DeploymentAnnotation}
-							returnedErr = multierror.Append(returnedErr, annotationConflictErr)
+							result.addError(newFinding(RuleAnnotationConflict, annotationConflictErr,
+								AnnotationElement, key, fmt.Sprintf("%s/annotation/deployment[%s]", containerPath, key), component.Attributes))
 							deploymentAnnotationDuplication[key] = true
 						}
 					} else {
@@ -132,7 +104,8 @@ func ValidateComponents(components []v1alpha2.Component) (returnedErr error) {
 						// only append the error for a single key once
 						if _, exist := serviceAnnotationDuplication[key]; !exist {
 							annotationConflictErr := &AnnotationConflictError{annotationName: key, annotationType: ServiceAnnotation}
-							returnedErr = multierror.Append(returnedErr, annotationConflictErr)
+							result.addError(newFinding(RuleAnnotationConflict, annotationConflictErr,
+								AnnotationElement, key, fmt.Sprintf("%s/annotation/service[%s]", containerPath, key), component.Attributes))
 							serviceAnnotationDuplication[key] = true
 						}
 					} else {
@@ -141,86 +114,163 @@ func ValidateComponents(components []v1alpha2.Component) (returnedErr error) {
 				}
 			}
 
-			err := validateEndpoints(component.Container.Endpoints, processedEndPointPort, processedEndPointName)
-			if len(err) > 0 {
-				for _, endpointErr := range err {
-					returnedErr = multierror.Append(returnedErr, resolveErrorMessageWithImportAttributes(endpointErr, component.Attributes))
-				}
+			// endpoint target ports are unique within a pod, a container with a dedicated pod does not conflict with the others
+			pod := getPod(component)
+			if processedPodEndPointPort[pod] == nil {
+				processedPodEndPointPort[pod] = make(map[int]string)
+			}
+			for _, endpointErr := range validateEndpoints(component.Name, component.Container.Endpoints, processedPodEndPointPort[pod], processedEndPointName) {
+				result.addError(newEndpointFinding(component, component.Container.Endpoints, endpointErr))
+			}
+
+			// Check if the volume mounts mentioned in the containers are referenced by a volume component
+			for volumeMountIndex, volumeMount := range component.Container.VolumeMounts {
+				volumeMounts = append(volumeMounts, volumeMountReference{component: component, volumeMount: volumeMount,
+					pointer: fmt.Sprintf("/components/%d/container/volumeMounts/%d/name", componentIndex, volumeMountIndex)})
 			}
 		case component.Volume != nil:
 			processedVolumes[component.Name] = true
-			if len(component.Volume.Size) > 0 {
-				// We use the Kube API for validation because there are so many ways to
-				// express storage in Kubernetes. For reference, you may check doc
-				// https://kubernetes.io/docs/concepts/configuration/manage-resources-containers/
-				if _, err := resource.ParseQuantity(component.Volume.Size); err != nil {
-					invalidVolErr := &InvalidVolumeError{name: component.Name, reason: fmt.Sprintf("size %s for volume component is invalid, %v. Example - 2Gi, 1024Mi", component.Volume.Size, err)}
-					returnedErr = multierror.Append(returnedErr, resolveErrorMessageWithImportAttributes(invalidVolErr, component.Attributes))
-				}
-			}
+			volumeNames = append(volumeNames, component.Name)
 		case component.Openshift != nil:
-			if component.Openshift.Uri != "" {
-				err := ValidateURI(component.Openshift.Uri)
-				if err != nil {
-					returnedErr = multierror.Append(returnedErr, resolveErrorMessageWithImportAttributes(err, component.Attributes))
-				}
-			}
-			currentComponentEndPointPort := make(map[int]bool)
-			err := validateDuplicatedName(component.Openshift.Endpoints, processedEndPointName, currentComponentEndPointPort)
-			if len(err) > 0 {
-				for _, endpointErr := range err {
-					returnedErr = multierror.Append(returnedErr, resolveErrorMessageWithImportAttributes(endpointErr, component.Attributes))
-				}
+			for _, endpointErr := range validateDuplicatedName(component.Openshift.Endpoints, processedEndPointName) {
+				result.addError(newEndpointFinding(component, component.Openshift.Endpoints, endpointErr))
 			}
 		case component.Kubernetes != nil:
-			if component.Kubernetes.Uri != "" {
-				err := ValidateURI(component.Kubernetes.Uri)
-				if err != nil {
-					returnedErr = multierror.Append(returnedErr, resolveErrorMessageWithImportAttributes(err, component.Attributes))
-				}
+			for _, endpointErr := range validateDuplicatedName(component.Kubernetes.Endpoints, processedEndPointName) {
+				result.addError(newEndpointFinding(component, component.Kubernetes.Endpoints, endpointErr))
 			}
-			currentComponentEndPointPort := make(map[int]bool)
-			err := validateDuplicatedName(component.Kubernetes.Endpoints, processedEndPointName, currentComponentEndPointPort)
-			if len(err) > 0 {
-				for _, endpointErr := range err {
-					returnedErr = multierror.Append(returnedErr, resolveErrorMessageWithImportAttributes(endpointErr, component.Attributes))
-				}
+		}
+	}
+
+	for _, reference := range volumeMounts {
+		if !processedVolumes[reference.volumeMount.Name] {
+			missingVolumeMountErr := &MissingVolumeMountError{volumeName: reference.volumeMount.Name, componentName: reference.component.Name}
+			finding := newFinding(RuleMissingVolumeMount, missingVolumeMountErr,
+				VolumeMountElement, reference.volumeMount.Name,
+				fmt.Sprintf("%s/volumeMounts[name=%s]", componentTypePath(reference.component), reference.volumeMount.Name),
+				reference.component.Attributes)
+			addKeySuggestion(finding, reference.volumeMount.Name, reference.pointer, volumeNames)
+			result.addError(finding)
+		}
+	}
+	result.Merge(validateUnusedVolumes(components, volumeMounts))
+
+	return result
+}
+
+// validateComponentContent runs the checks of the component which only depend on the component content:
+// the image reference, the container env, resource requirements, endpoint fields and volume mount paths, the volume size,
+// the openshift and kubernetes uri, endpoint fields and inlined manifest, the image dockerfile and the plugin import reference
+func validateComponentContent(component v1alpha2.Component) (result ValidationResult) {
+	result.Merge(validateImageReference(component))
+
+	switch {
+	case component.Container != nil:
+		containerPath := componentTypePath(component)
+
+		// Check if any containers are customizing the reserved PROJECT_SOURCE or PROJECTS_ROOT env,
+		// and if the env names are valid and unique
+		result.Merge(validateContainerEnv(component))
+
+		var err error
+		var memoryLimit, cpuLimit, memoryRequest, cpuRequest resource.Quantity
+		if component.Container.MemoryLimit != "" {
+			memoryLimit, err = resource.ParseQuantity(component.Container.MemoryLimit)
+			if err != nil {
+				parseQuantityErr := &ParsingResourceRequirementError{resource: MemoryLimit, cmpName: component.Name, errMsg: err.Error()}
+				result.addError(newFinding(RuleResourceQuantity, parseQuantityErr,
+					ComponentElement, component.Name, fmt.Sprintf("%s/%s", containerPath, MemoryLimit), component.Attributes))
 			}
-		case component.Image != nil:
-			var gitSource v1alpha2.GitLikeProjectSource
-			if component.Image.Dockerfile != nil && component.Image.Dockerfile.Git != nil {
-				gitSource = component.Image.Dockerfile.Git.GitLikeProjectSource
-				if err := validateSingleRemoteGitSrc("component", component.Name, gitSource); err != nil {
-					returnedErr = multierror.Append(returnedErr, resolveErrorMessageWithImportAttributes(err, component.Attributes))
-				}
+		}
+		if component.Container.CpuLimit != "" {
+			cpuLimit, err = resource.ParseQuantity(component.Container.CpuLimit)
+			if err != nil {
+				parseQuantityErr := &ParsingResourceRequirementError{resource: CpuLimit, cmpName: component.Name, errMsg: err.Error()}
+				result.addError(newFinding(RuleResourceQuantity, parseQuantityErr,
+					ComponentElement, component.Name, fmt.Sprintf("%s/%s", containerPath, CpuLimit), component.Attributes))
 			}
-		case component.Plugin != nil:
-			if component.Plugin.RegistryUrl != "" {
-				err := ValidateURI(component.Plugin.RegistryUrl)
-				if err != nil {
-					returnedErr = multierror.Append(returnedErr, resolveErrorMessageWithImportAttributes(err, component.Attributes))
-				}
+		}
+		if component.Container.MemoryRequest != "" {
+			memoryRequest, err = resource.ParseQuantity(component.Container.MemoryRequest)
+			if err != nil {
+				parseQuantityErr := &ParsingResourceRequirementError{resource: MemoryRequest, cmpName: component.Name, errMsg: err.Error()}
+				result.addError(newFinding(RuleResourceQuantity, parseQuantityErr,
+					ComponentElement, component.Name, fmt.Sprintf("%s/%s", containerPath, MemoryRequest), component.Attributes))
+			} else if !memoryLimit.IsZero() && memoryRequest.Cmp(memoryLimit) > 0 {
+				invalidResourceRequest := &InvalidResourceRequestError{cmpName: component.Name, errMsg: fmt.Sprintf("memoryRequest is greater than memoryLimit.")}
+				result.addError(newFinding(RuleResourceRequest, invalidResourceRequest,
+					ComponentElement, component.Name, fmt.Sprintf("%s/%s", containerPath, MemoryRequest), component.Attributes))
+			}
+		}
+		if component.Container.CpuRequest != "" {
+			cpuRequest, err = resource.ParseQuantity(component.Container.CpuRequest)
+			if err != nil {
+				parseQuantityErr := &ParsingResourceRequirementError{resource: CpuRequest, cmpName: component.Name, errMsg: err.Error()}
+				result.addError(newFinding(RuleResourceQuantity, parseQuantityErr,
+					ComponentElement, component.Name, fmt.Sprintf("%s/%s", containerPath, CpuRequest), component.Attributes))
+			} else if !cpuLimit.IsZero() && cpuRequest.Cmp(cpuLimit) > 0 {
+				invalidResourceRequest := &InvalidResourceRequestError{cmpName: component.Name, errMsg: fmt.Sprintf("cpuRequest is greater than cpuLimit.")}
+				result.addError(newFinding(RuleResourceRequest, invalidResourceRequest,
+					ComponentElement, component.Name, fmt.Sprintf("%s/%s", containerPath, CpuRequest), component.Attributes))
 			}
 		}
 
-	}
-
-	// Check if the volume mounts mentioned in the containers are referenced by a volume component
-	var invalidVolumeMountsErrList []string
-	for componentName, volumeMountNames := range processedVolumeMounts {
-		for _, volumeMountName := range volumeMountNames {
-			if !processedVolumes[volumeMountName] {
-				missingVolumeMountErr := fmt.Errorf("volume mount %s belonging to the container component %s", volumeMountName, componentName)
-				newErr := resolveErrorMessageWithImportAttributes(missingVolumeMountErr, processedComponentWithVolumeMounts[componentName].Attributes)
-				invalidVolumeMountsErrList = append(invalidVolumeMountsErrList, newErr.Error())
+		result.Merge(validateEndpointFields(component, component.Container.Endpoints))
+		result.Merge(validateVolumeMountPaths(component))
+	case component.Volume != nil:
+		result.Merge(validateVolume(component))
+		if len(component.Volume.Size) > 0 {
+			// We use the Kube API for validation because there are so many ways to
+			// express storage in Kubernetes. For reference, you may check doc
+			// https://kubernetes.io/docs/concepts/configuration/manage-resources-containers/
+			if _, err := resource.ParseQuantity(component.Volume.Size); err != nil {
+				invalidVolErr := &InvalidVolumeError{name: component.Name, reason: fmt.Sprintf("size %s for volume component is invalid, %v. Example - 2Gi, 1024Mi", component.Volume.Size, err)}
+				result.addError(newFinding(RuleVolumeSize, invalidVolErr,
+					ComponentElement, component.Name, fmt.Sprintf("%s/size", componentTypePath(component)), component.Attributes))
+			}
+		}
+	case component.Openshift != nil:
+		if component.Openshift.Uri != "" {
+			result.Merge(validateURIField(component.Openshift.Uri,
+				ComponentElement, component.Name, fmt.Sprintf("%s/uri", componentTypePath(component)), component.Attributes))
+		}
+		result.Merge(validateEndpointFields(component, component.Openshift.Endpoints))
+		if component.Openshift.Inlined != "" {
+			result.Merge(validateInlinedManifest(component, component.Openshift.Inlined, component.Openshift.Endpoints))
+		}
+	case component.Kubernetes != nil:
+		if component.Kubernetes.Uri != "" {
+			result.Merge(validateURIField(component.Kubernetes.Uri,
+				ComponentElement, component.Name, fmt.Sprintf("%s/uri", componentTypePath(component)), component.Attributes))
+		}
+		result.Merge(validateEndpointFields(component, component.Kubernetes.Endpoints))
+		if component.Kubernetes.Inlined != "" {
+			result.Merge(validateInlinedManifest(component, component.Kubernetes.Inlined, component.Kubernetes.Endpoints))
+		}
+	case component.Image != nil:
+		var gitSource v1alpha2.GitLikeProjectSource
+		if component.Image.Dockerfile != nil && component.Image.Dockerfile.Git != nil {
+			gitSource = component.Image.Dockerfile.Git.GitLikeProjectSource
+			if err := validateSingleRemoteGitSrc("component", component.Name, gitSource); err != nil {
+				result.addError(newFinding(RuleGitRemote, err,
+					ComponentElement, component.Name, fmt.Sprintf("%s/dockerfile/git", componentTypePath(component)), component.Attributes))
 			}
 		}
+		if component.Image.Dockerfile != nil {
+			result.Merge(validateDockerfileImage(component))
+		}
+	case component.Plugin != nil:
+		result.Merge(validatePluginImportReference(component))
 	}
 
-	if len(invalidVolumeMountsErrList) > 0 {
-		invalidVolumeMountsErr := fmt.Sprintf("\n%s", strings.Join(invalidVolumeMountsErrList, "\n"))
-		returnedErr = multierror.Append(returnedErr, &MissingVolumeMountError{errMsg: invalidVolumeMountsErr})
-	}
+	return result
+}
+
+// volumeMountReference is a container volume mount along with the container component it belongs to
+type volumeMountReference struct {
+	component   v1alpha2.Component
+	volumeMount v1alpha2.VolumeMount
 
-	return returnedErr
+	// pointer is the JSON pointer to the volume mount name, e.g. /components/0/container/volumeMounts/1/name
+	pointer string
 }
@@ -130,7 +130,7 @@ func generateDummyImageComponent(name string, src v1alpha2.DockerfileSrc) v1alph
 						Dockerfile: &v1alpha2.DockerfileImage{
 							DockerfileSrc: src,
 							Dockerfile: v1alpha2.Dockerfile{
-								BuildContext: "/path",
+								BuildContext: "${PROJECT_SOURCE}/path",
 							},
 						},
 					},
@@ -149,6 +149,9 @@ func generateDummyPluginComponent(name, url string, compAttribute attributes.Att
 		ComponentUnion: v1alpha2.ComponentUnion{
 			Plugin: &v1alpha2.PluginComponent{
 				ImportReference: v1alpha2.ImportReference{
+					ImportReferenceUnion: v1alpha2.ImportReferenceUnion{
+						Id: "java-maven",
+					},
 					RegistryUrl: url,
 				},
 			},
@@ -255,7 +258,8 @@ func TestValidateComponents(t *testing.T) {
 	endpointUrl28080 := generateDummyEndpoint("url2", 8080)
 	endpointUrl28081 := generateDummyEndpoint("url2", 8081)
 
-	invalidVolMountErr := ".*\nvolume mount myinvalidvol belonging to the container component.*\nvolume mount myinvalidvol2 belonging to the container component.*"
+	invalidVolMountErr := ".*volume mount myinvalidvol belonging to the container component container1"
+	invalidVolMount2Err := ".*volume mount myinvalidvol2 belonging to the container component container1"
 	duplicateComponentErr := "duplicate key: component1"
 	reservedEnvErr := "env variable .* is reserved and cannot be customized in component.*"
 	invalidSizeErr := "size .* for volume component is invalid"
@@ -324,7 +328,7 @@ func TestValidateComponents(t *testing.T) {
 				generateDummyVolumeComponent("myvol", "1Gi"),
 				generateDummyContainerComponent("container1", invalidVolMounts, nil, nil, v1alpha2.Annotation{}, false),
 			},
-			wantErr: []string{invalidVolMountErr},
+			wantErr: []string{invalidVolMountErr, invalidVolMount2Err},
 		},
 		{
 			name: "Invalid containers with the same endpoint names",
@@ -342,6 +346,23 @@ func TestValidateComponents(t *testing.T) {
 			},
 			wantErr: []string{sameTargetPortErr},
 		},
+		{
+			name: "Valid containers with the same endpoint target ports when dedicatedPod is set to true",
+			components: []v1alpha2.Component{
+				generateDummyContainerComponent("name1", nil, []v1alpha2.Endpoint{endpointUrl18080}, nil, v1alpha2.Annotation{}, false),
+				generateDummyContainerComponent("name2", nil, []v1alpha2.Endpoint{endpointUrl28080}, nil, v1alpha2.Annotation{}, true),
+				generateDummyContainerComponent("name3", nil, []v1alpha2.Endpoint{generateDummyEndpoint("url3", 8080)}, nil, v1alpha2.Annotation{}, true),
+			},
+		},
+		{
+			name: "Invalid containers with the same endpoint target ports in the main pod along with a dedicatedPod container",
+			components: []v1alpha2.Component{
+				generateDummyContainerComponent("name1", nil, []v1alpha2.Endpoint{endpointUrl18080}, nil, v1alpha2.Annotation{}, false),
+				generateDummyContainerComponent("name2", nil, []v1alpha2.Endpoint{endpointUrl28080}, nil, v1alpha2.Annotation{}, true),
+				generateDummyContainerComponent("name3", nil, []v1alpha2.Endpoint{generateDummyEndpoint("url3", 8080)}, nil, v1alpha2.Annotation{}, false),
+			},
+			wantErr: []string{"devfile contains multiple containers with same endpoint targetPort: 8080, used by both container components name1 and name3 in the same pod"},
+		},
 		{
 			name: "Valid container with same target ports in a single component",
 			components: []v1alpha2.Component{
@@ -567,7 +588,7 @@ func TestValidateComponents(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			err := ValidateComponents(tt.components)
+			err := ValidateComponents(tt.components).Err()
 
 			merr, ok := err.(*multierror.Error)
 			if ok {
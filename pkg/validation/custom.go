@@ -0,0 +1,136 @@
+//
+//
+// Copyright Red Hat
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validation
+
+import (
+	"encoding/json"
+	"fmt"
+	"sync"
+
+	"github.com/devfile/api/v2/pkg/apis/workspaces/v1alpha2"
+	attributesAPI "github.com/devfile/api/v2/pkg/attributes"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// CustomClassKind is the kind of devfile element a custom class applies to
+type CustomClassKind string
+
+const (
+	// ProjectSourceClassKind is the kind of the projectSourceClass of the custom project sources
+	ProjectSourceClassKind CustomClassKind = "projectSourceClass"
+	// CommandClassKind is the kind of the commandClass of the custom commands
+	CommandClassKind CustomClassKind = "commandClass"
+	// ComponentClassKind is the kind of the componentClass of the custom components
+	ComponentClassKind CustomClassKind = "componentClass"
+)
+
+// EmbeddedResourceParser parses the embedded resource of a custom project source, command or component,
+// an error is returned if the embedded resource is invalid for the custom class
+type EmbeddedResourceParser func(embeddedResource runtime.RawExtension) error
+
+// CustomClassRegistry holds the known classes of the custom project sources, commands and components along with
+// the parsers of their embedded resources
+type CustomClassRegistry struct {
+	mutex   sync.RWMutex
+	classes map[CustomClassKind]map[string]EmbeddedResourceParser
+}
+
+// NewCustomClassRegistry returns an empty custom class registry
+func NewCustomClassRegistry() *CustomClassRegistry {
+	return &CustomClassRegistry{classes: make(map[CustomClassKind]map[string]EmbeddedResourceParser)}
+}
+
+// Register adds a known custom class of the given kind. If the parser is nil, the embedded resource is only
+// checked to be valid JSON
+func (r *CustomClassRegistry) Register(kind CustomClassKind, class string, parser EmbeddedResourceParser) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if r.classes[kind] == nil {
+		r.classes[kind] = make(map[string]EmbeddedResourceParser)
+	}
+	r.classes[kind][class] = parser
+}
+
+// validate checks the class is a known custom class of the given kind and the embedded resource parses
+func (r *CustomClassRegistry) validate(kind CustomClassKind, class string, embeddedResource runtime.RawExtension) error {
+	r.mutex.RLock()
+	parser, ok := r.classes[kind][class]
+	r.mutex.RUnlock()
+
+	if !ok {
+		return &UnknownCustomClassError{kind: kind, class: class}
+	}
+
+	var err error
+	if parser != nil {
+		err = parser(embeddedResource)
+	} else if len(embeddedResource.Raw) > 0 && !json.Valid(embeddedResource.Raw) {
+		err = fmt.Errorf("the embedded resource is not valid JSON")
+	}
+	if err != nil {
+		return &InvalidEmbeddedResourceError{kind: kind, class: class, reason: err.Error()}
+	}
+	return nil
+}
+
+// validateCustomClasses checks the custom project sources, commands and components reference a known custom class of
+// the registry and their embedded resources parse
+func validateCustomClasses(workspaceTemplateSpec *v1alpha2.DevWorkspaceTemplateSpec, registry *CustomClassRegistry) (result ValidationResult) {
+	for _, component := range workspaceTemplateSpec.Components {
+		if component.Custom == nil {
+			continue
+		}
+		if err := registry.validate(ComponentClassKind, component.Custom.ComponentClass, component.Custom.EmbeddedResource); err != nil {
+			result.addError(newFinding(RuleCustomClass, err, ComponentElement, component.Name,
+				componentTypePath(component), component.Attributes))
+		}
+	}
+
+	for _, command := range workspaceTemplateSpec.Commands {
+		if command.Custom == nil {
+			continue
+		}
+		if err := registry.validate(CommandClassKind, command.Custom.CommandClass, command.Custom.EmbeddedResource); err != nil {
+			result.addError(newFinding(RuleCustomClass, err, CommandElement, command.Id, commandTypePath(command), command.Attributes))
+		}
+	}
+
+	for _, project := range workspaceTemplateSpec.Projects {
+		result.Merge(validateCustomProjectSource(registry, project.ProjectSource, project.Name, ProjectElement, "projects", project.Attributes))
+	}
+	for _, project := range workspaceTemplateSpec.DependentProjects {
+		result.Merge(validateCustomProjectSource(registry, project.ProjectSource, project.Name, DependentProjectElement, "dependentProjects", project.Attributes))
+	}
+	for _, starterProject := range workspaceTemplateSpec.StarterProjects {
+		result.Merge(validateCustomProjectSource(registry, starterProject.ProjectSource, starterProject.Name, StarterProjectElement, "starterProjects", starterProject.Attributes))
+	}
+
+	return result
+}
+
+// validateCustomProjectSource checks the custom project source of a project of the given devfile top-level list
+func validateCustomProjectSource(registry *CustomClassRegistry, projectSource v1alpha2.ProjectSource, name string, kind ElementKind,
+	listName string, attributes attributesAPI.Attributes) (result ValidationResult) {
+	if projectSource.Custom == nil {
+		return result
+	}
+	if err := registry.validate(ProjectSourceClassKind, projectSource.Custom.ProjectSourceClass, projectSource.Custom.EmbeddedResource); err != nil {
+		result.addError(newFinding(RuleCustomClass, err, kind, name, fmt.Sprintf("/%s[name=%s]/custom", listName, name), attributes))
+	}
+	return result
+}
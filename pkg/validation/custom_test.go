@@ -0,0 +1,121 @@
+//
+//
+// Copyright Red Hat
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validation
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/devfile/api/v2/pkg/apis/workspaces/v1alpha2"
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func TestValidateCustomClasses(t *testing.T) {
+
+	registry := NewCustomClassRegistry()
+	registry.Register(ComponentClassKind, "che-theia", nil)
+	registry.Register(CommandClassKind, "vscode-task", nil)
+	registry.Register(ProjectSourceClassKind, "svn", func(embeddedResource runtime.RawExtension) error {
+		var svnSource struct {
+			Url string `json:"url"`
+		}
+		if err := json.Unmarshal(embeddedResource.Raw, &svnSource); err != nil {
+			return err
+		}
+		if svnSource.Url == "" {
+			return fmt.Errorf("url is required")
+		}
+		return nil
+	})
+
+	generateCustomComponent := func(class, embeddedResource string) v1alpha2.Component {
+		return v1alpha2.Component{
+			Name: "custom1",
+			ComponentUnion: v1alpha2.ComponentUnion{
+				Custom: &v1alpha2.CustomComponent{ComponentClass: class, EmbeddedResource: runtime.RawExtension{Raw: []byte(embeddedResource)}},
+			},
+		}
+	}
+	generateCustomCommand := func(class string) v1alpha2.Command {
+		return v1alpha2.Command{
+			Id: "custom1",
+			CommandUnion: v1alpha2.CommandUnion{
+				Custom: &v1alpha2.CustomCommand{CommandClass: class},
+			},
+		}
+	}
+	generateCustomProject := func(class, embeddedResource string) v1alpha2.Project {
+		return v1alpha2.Project{
+			Name: "project1",
+			ProjectSource: v1alpha2.ProjectSource{
+				Custom: &v1alpha2.CustomProjectSource{ProjectSourceClass: class, EmbeddedResource: runtime.RawExtension{Raw: []byte(embeddedResource)}},
+			},
+		}
+	}
+
+	unknownComponentClassErr := "componentClass \"unknown\" is not a known custom class"
+	unknownCommandClassErr := "commandClass \"unknown\" is not a known custom class"
+	invalidJSONErr := "the embedded resource of componentClass \"che-theia\" is invalid: the embedded resource is not valid JSON"
+	invalidSvnErr := "the embedded resource of projectSourceClass \"svn\" is invalid: url is required"
+
+	tests := []struct {
+		name       string
+		components []v1alpha2.Component
+		commands   []v1alpha2.Command
+		projects   []v1alpha2.Project
+		wantErr    []string
+	}{
+		{
+			name:       "Known custom classes",
+			components: []v1alpha2.Component{generateCustomComponent("che-theia", `{"version": "latest"}`)},
+			commands:   []v1alpha2.Command{generateCustomCommand("vscode-task")},
+			projects:   []v1alpha2.Project{generateCustomProject("svn", `{"url": "svn://example.com/repo"}`)},
+		},
+		{
+			name:       "Unknown custom classes",
+			components: []v1alpha2.Component{generateCustomComponent("unknown", "")},
+			commands:   []v1alpha2.Command{generateCustomCommand("unknown")},
+			wantErr:    []string{unknownComponentClassErr, unknownCommandClassErr},
+		},
+		{
+			name:       "Embedded resources that do not parse",
+			components: []v1alpha2.Component{generateCustomComponent("che-theia", `{"version": `)},
+			projects:   []v1alpha2.Project{generateCustomProject("svn", `{"path": "trunk"}`)},
+			wantErr:    []string{invalidJSONErr, invalidSvnErr},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			spec := &v1alpha2.DevWorkspaceTemplateSpec{
+				DevWorkspaceTemplateSpecContent: v1alpha2.DevWorkspaceTemplateSpecContent{
+					Components: tt.components,
+					Commands:   tt.commands,
+					Projects:   tt.projects,
+				},
+			}
+			result := validateCustomClasses(spec, registry)
+
+			if assert.Equal(t, len(tt.wantErr), len(result.Errors), "Error list length should match") {
+				for i := 0; i < len(result.Errors); i++ {
+					assert.Regexp(t, tt.wantErr[i], result.Errors[i].Error(), "Error message should match")
+				}
+			}
+		})
+	}
+}
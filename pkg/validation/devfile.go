@@ -0,0 +1,266 @@
+//
+//
+// Copyright Red Hat
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validation
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+
+	"github.com/devfile/api/v2/pkg/apis/workspaces/v1alpha2"
+	attributesAPI "github.com/devfile/api/v2/pkg/attributes"
+	"github.com/devfile/api/v2/pkg/validation/variables"
+)
+
+// schemaVersionRegex is the pattern of the devfile schemaVersion
+var schemaVersionRegex = regexp.MustCompile(`^([2-9])\.([0-9]+)\.([0-9]+)(\-[0-9a-z-]+(\.[0-9a-z-]+)*)?(\+[0-9A-Za-z-]+(\.[0-9A-Za-z-]+)*)?$`)
+
+// ValidationOptions are the options of a devfile validation
+type ValidationOptions struct {
+	// SkipVariableSubstitution skips the validation and substitution of the global variable references
+	SkipVariableSubstitution bool
+
+	// SchemaVersion is the devfile schema version to validate against. When validating a devfile,
+	// it defaults to the schemaVersion declared in the devfile
+	SchemaVersion string
+
+	// Rules is the registry of the custom and disabled rules, defaults to DefaultRuleRegistry
+	Rules *RuleRegistry
+
+	// ImagePolicy is the pinning policy the container and image component images are checked against,
+	// after the variable substitution. The images are not checked against a pinning policy if not set
+	ImagePolicy *ImagePolicy
+
+	// CustomClasses is the registry of the known custom classes. If set, the custom project sources, commands and
+	// components must reference a known custom class and their embedded resources must parse
+	CustomClasses *CustomClassRegistry
+
+	// VariableSources are the external variable sources, e.g. a CLI flag, the environment or a values file, in precedence order.
+	// The external sources take precedence over the variables of the devfile and of its parent
+	VariableSources []variables.VariableSource
+
+	// UnescapeVariableReferences replaces the escaped variable references, e.g. \{{.State}}, with their literal text once the
+	// devfile is validated, see variables.UnescapeVariableReferences. It is meant for the final output, since validating the
+	// unescaped devfile again reports the literal references as invalid variable references
+	UnescapeVariableReferences bool
+}
+
+// ValidateDevfile validates the whole devfile and reports all the findings together. The global variable references
+// are validated and replaced in place, unless disabled by the options, before the devfile content is validated in the order of
+// 1. schema version, and the constructs introduced after it
+// 2. parent
+// 3. components
+// 4. commands
+// 5. events
+// 6. projects, dependent projects and starter projects
+// 7. resource budget of the container components against the global memory limit
+// The escaped variable references are unescaped once the devfile is validated if enabled by the options
+func ValidateDevfile(devfile *v1alpha2.Devfile, options ValidationOptions) ValidationResult {
+	return validateDevfile(devfile, options, nil)
+}
+
+// validateDevfile validates the whole devfile, the checks of the components and commands are looked up in the cache
+func validateDevfile(devfile *v1alpha2.Devfile, options ValidationOptions, cache *validationCache) (result ValidationResult) {
+	if devfile == nil {
+		return result
+	}
+
+	if options.SchemaVersion == "" {
+		options.SchemaVersion = devfile.SchemaVersion
+	}
+	if !schemaVersionRegex.MatchString(options.SchemaVersion) {
+		result.addError(newFinding(RuleSchemaVersion, &InvalidSchemaVersionError{schemaVersion: options.SchemaVersion},
+			SchemaVersionElement, "", "/schemaVersion", nil))
+	}
+
+	result.Merge(validateDevWorkspaceTemplateSpec(&devfile.DevWorkspaceTemplateSpec, options, cache))
+	result.Merge(validateResourceBudget(devfile.Metadata.GlobalMemoryLimit, devfile.Components))
+
+	return options.rules().filter(result)
+}
+
+// ValidateDevWorkspaceTemplateSpec validates the devworkspace template spec with the same rules as ValidateDevfile,
+// the constructs introduced after the schema version are only reported if the schema version is set in the options
+func ValidateDevWorkspaceTemplateSpec(workspaceTemplateSpec *v1alpha2.DevWorkspaceTemplateSpec, options ValidationOptions) ValidationResult {
+	return validateDevWorkspaceTemplateSpec(workspaceTemplateSpec, options, nil)
+}
+
+// validateDevWorkspaceTemplateSpec validates the devworkspace template spec, the checks of the components and commands
+// are looked up in the cache
+func validateDevWorkspaceTemplateSpec(workspaceTemplateSpec *v1alpha2.DevWorkspaceTemplateSpec, options ValidationOptions, cache *validationCache) (result ValidationResult) {
+	if workspaceTemplateSpec == nil {
+		return result
+	}
+
+	rules := options.rules()
+
+	if options.SchemaVersion != "" && schemaVersionRegex.MatchString(options.SchemaVersion) {
+		result.Merge(validateSchemaFeatures(options.SchemaVersion, workspaceTemplateSpec))
+	}
+
+	if !options.SkipVariableSubstitution {
+		sources := append(append([]variables.VariableSource{}, options.VariableSources...), variables.DevfileVariableSources(workspaceTemplateSpec)...)
+		result.Merge(ValidateGlobalVariables(workspaceTemplateSpec, sources))
+	}
+
+	if workspaceTemplateSpec.Parent != nil {
+		result.Merge(validateParent(workspaceTemplateSpec.Parent))
+	}
+
+	components := workspaceTemplateSpec.Components
+	commands := workspaceTemplateSpec.Commands
+	result.Merge(validateComponents(components, cache))
+	result.Merge(rules.run(ComponentsScope, RuleContext{Components: components}))
+	if options.ImagePolicy != nil {
+		result.Merge(validateImagePolicy(components, *options.ImagePolicy))
+	}
+	result.Merge(validateCommands(commands, components, cache))
+	result.Merge(rules.run(CommandsScope, RuleContext{Commands: commands, Components: components}))
+	if events := workspaceTemplateSpec.Events; events != nil {
+		result.Merge(validateEvents(*events, commands))
+		result.Merge(rules.run(EventsScope, RuleContext{Events: events, Commands: commands}))
+	}
+	result.Merge(validateProjects(workspaceTemplateSpec.Projects, "projects", ProjectElement))
+	result.Merge(validateProjects(workspaceTemplateSpec.DependentProjects, "dependentProjects", DependentProjectElement))
+	result.Merge(validateProjectConflicts(workspaceTemplateSpec.Projects, workspaceTemplateSpec.DependentProjects))
+	result.Merge(validateStarterProjects(workspaceTemplateSpec.StarterProjects))
+	if options.CustomClasses != nil {
+		result.Merge(validateCustomClasses(workspaceTemplateSpec, options.CustomClasses))
+	}
+
+	if !options.SkipVariableSubstitution && options.UnescapeVariableReferences {
+		variables.UnescapeVariableReferences(workspaceTemplateSpec)
+	}
+
+	return rules.filter(result)
+}
+
+// rules returns the rule registry of the options, or DefaultRuleRegistry if not set
+func (options ValidationOptions) rules() *RuleRegistry {
+	if options.Rules == nil {
+		return DefaultRuleRegistry
+	}
+	return options.Rules
+}
+
+// ValidateGlobalVariables validates the workspace template spec for global variable references and replaces them with the
+// variable values of the sources, see variables.ValidateAndReplaceGlobalVariableWithSources. The VariableWarning of the
+// variables package, which cannot depend on this package, is reported as findings, see newVariableWarningResult
+func ValidateGlobalVariables(workspaceTemplateSpec *v1alpha2.DevWorkspaceTemplateSpec, sources []variables.VariableSource) ValidationResult {
+	if workspaceTemplateSpec == nil {
+		return ValidationResult{}
+	}
+	variableWarning := variables.ValidateAndReplaceGlobalVariableWithSources(workspaceTemplateSpec, sources)
+	return newVariableWarningResult(variableWarning, workspaceTemplateSpec)
+}
+
+// newVariableWarningResult returns the invalid global variable references of each devfile element as warnings,
+// the variables referencing each other in a cycle, along with the map keys whose replacement collides with another key, as errors,
+// and the source supplying each variable value, along with the undefined variables referenced in the devfile, as informational findings
+func newVariableWarningResult(variableWarning variables.VariableWarning, workspaceTemplateSpec *v1alpha2.DevWorkspaceTemplateSpec) (result ValidationResult) {
+	addWarnings := func(warnings map[string][]string, kind ElementKind, listName, keyName string, attributes map[string]attributesAPI.Attributes) {
+		var keys []string
+		for key := range warnings {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+
+		for _, key := range keys {
+			result.addWarning(newFinding(RuleVariableReference, &variables.InvalidKeysError{Keys: warnings[key]},
+				kind, key, fmt.Sprintf("/%s[%s=%s]", listName, keyName, key), attributes[key]))
+		}
+	}
+
+	componentAttributes := make(map[string]attributesAPI.Attributes)
+	for _, component := range workspaceTemplateSpec.Components {
+		componentAttributes[component.Name] = component.Attributes
+	}
+	commandAttributes := make(map[string]attributesAPI.Attributes)
+	for _, command := range workspaceTemplateSpec.Commands {
+		commandAttributes[command.Id] = command.Attributes
+	}
+	projectAttributes := make(map[string]attributesAPI.Attributes)
+	for _, project := range workspaceTemplateSpec.Projects {
+		projectAttributes[project.Name] = project.Attributes
+	}
+	dependentProjectAttributes := make(map[string]attributesAPI.Attributes)
+	for _, project := range workspaceTemplateSpec.DependentProjects {
+		dependentProjectAttributes[project.Name] = project.Attributes
+	}
+	starterProjectAttributes := make(map[string]attributesAPI.Attributes)
+	for _, starterProject := range workspaceTemplateSpec.StarterProjects {
+		starterProjectAttributes[starterProject.Name] = starterProject.Attributes
+	}
+
+	addWarnings(variableWarning.Components, ComponentElement, "components", "name", componentAttributes)
+	addWarnings(variableWarning.Commands, CommandElement, "commands", "id", commandAttributes)
+	addWarnings(variableWarning.Projects, ProjectElement, "projects", "name", projectAttributes)
+	addWarnings(variableWarning.DependentProjects, DependentProjectElement, "dependentProjects", "name", dependentProjectAttributes)
+	addWarnings(variableWarning.StarterProjects, StarterProjectElement, "starterProjects", "name", starterProjectAttributes)
+
+	var variableKeys []string
+	for key := range variableWarning.Variables {
+		variableKeys = append(variableKeys, key)
+	}
+	sort.Strings(variableKeys)
+	for _, key := range variableKeys {
+		result.addWarning(newFinding(RuleVariableReference, &variables.InvalidKeysError{Keys: variableWarning.Variables[key]},
+			VariableElement, key, fmt.Sprintf("/variables/%s", key), nil))
+	}
+	for _, err := range variableWarning.Cycles {
+		var key string
+		switch cycleErr := err.(type) {
+		case *variables.SelfReferenceError:
+			key = cycleErr.Key
+		case *variables.ReferenceCycleError:
+			key = cycleErr.Cycle[0]
+		}
+		result.addError(newFinding(RuleVariableCycle, err, VariableElement, key, fmt.Sprintf("/variables/%s", key), nil))
+	}
+
+	type elementList struct {
+		kind       ElementKind
+		keyName    string
+		attributes map[string]attributesAPI.Attributes
+	}
+	elementLists := map[string]elementList{
+		"components":        {ComponentElement, "name", componentAttributes},
+		"commands":          {CommandElement, "id", commandAttributes},
+		"projects":          {ProjectElement, "name", projectAttributes},
+		"dependentProjects": {DependentProjectElement, "name", dependentProjectAttributes},
+		"starterProjects":   {StarterProjectElement, "name", starterProjectAttributes},
+	}
+	var sourceKeys []string
+	for key := range variableWarning.Sources {
+		sourceKeys = append(sourceKeys, key)
+	}
+	sort.Strings(sourceKeys)
+	for _, key := range sourceKeys {
+		result.addInfo(newFinding(RuleVariableSource, &VariableSourceInfo{key: key, source: variableWarning.Sources[key]},
+			VariableElement, key, fmt.Sprintf("/variables/%s", key), nil))
+	}
+	for _, key := range variableWarning.Undefined {
+		result.addInfo(newFinding(RuleUndefinedVariable, &UndefinedVariableInfo{key: key}, VariableElement, key, fmt.Sprintf("/variables/%s", key), nil))
+	}
+	for _, collision := range variableWarning.KeyCollisions {
+		list := elementLists[collision.ElementList]
+		result.addError(newFinding(RuleVariableKeyCollision, collision, list.kind, collision.Element,
+			fmt.Sprintf("/%s[%s=%s]", collision.ElementList, list.keyName, collision.Element), list.attributes[collision.Element]))
+	}
+
+	return result
+}
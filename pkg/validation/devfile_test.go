@@ -0,0 +1,411 @@
+//
+//
+// Copyright Red Hat
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validation
+
+import (
+	"io/ioutil"
+	"testing"
+
+	"github.com/devfile/api/v2/pkg/apis/workspaces/v1alpha2"
+	"github.com/devfile/api/v2/pkg/attributes"
+	"github.com/devfile/api/v2/pkg/devfile"
+	"github.com/devfile/api/v2/pkg/validation/variables"
+	"github.com/stretchr/testify/assert"
+	"sigs.k8s.io/yaml"
+)
+
+// generateDummyDevfile returns a dummy devfile for testing
+func generateDummyDevfile(schemaVersion string, variables map[string]string, components []v1alpha2.Component, commands []v1alpha2.Command,
+	projects []v1alpha2.Project, dependentProjects []v1alpha2.Project) *v1alpha2.Devfile {
+	return &v1alpha2.Devfile{
+		DevfileHeader: devfile.DevfileHeader{
+			SchemaVersion: schemaVersion,
+		},
+		DevWorkspaceTemplateSpec: v1alpha2.DevWorkspaceTemplateSpec{
+			DevWorkspaceTemplateSpecContent: v1alpha2.DevWorkspaceTemplateSpecContent{
+				Variables:         variables,
+				Components:        components,
+				Commands:          commands,
+				Projects:          projects,
+				DependentProjects: dependentProjects,
+			},
+		},
+	}
+}
+
+func TestValidateDevfile(t *testing.T) {
+
+	component := "alias1"
+
+	volMounts := []v1alpha2.VolumeMount{
+		{
+			Name: "myvol",
+		},
+	}
+
+	components := []v1alpha2.Component{
+		generateDummyContainerComponent(component, nil, nil, nil, v1alpha2.Annotation{}, false),
+	}
+
+	// generateVariableImageComponent returns a container component referencing the image variable,
+	// the component is substituted in place so each test case needs its own copy
+	generateVariableImageComponent := func() []v1alpha2.Component {
+		imageComponent := generateDummyContainerComponent(component, nil, nil, nil, v1alpha2.Annotation{}, false)
+		imageComponent.Container.Image = "{{image}}"
+		return []v1alpha2.Component{imageComponent}
+	}
+
+	commands := []v1alpha2.Command{
+		generateDummyExecCommand("run1", component, &v1alpha2.CommandGroup{Kind: runGroup}),
+		generateDummyExecCommand("run2", component, &v1alpha2.CommandGroup{Kind: runGroup}),
+	}
+
+	projectWithTwoRemotes := generateDummyGitProject("project1", nil, map[string]string{"origin": "originremote", "test": "testremote"}, attributes.Attributes{})
+
+	invalidSchemaVersionErr := "schema version \"1.0.0\" is invalid"
+	missingVolumeMountErr := ".*volume mount myvol belonging to the container component alias1"
+	missingCheckoutErr := "project project1 has more than one remote defined, but has no checkoutfrom remote defined"
+	noDefaultCmdWarning := ".*there should be exactly one default command, currently there is no default command"
+	invalidVariableWarning := "invalid variable references - image"
+
+	tests := []struct {
+		name        string
+		devfile     *v1alpha2.Devfile
+		options     ValidationOptions
+		wantErr     []string
+		wantWarning []string
+		wantImage   string
+	}{
+		{
+			name:    "Valid devfile",
+			devfile: generateDummyDevfile("2.2.0", nil, components, nil, nil, nil),
+		},
+		{
+			name:    "Invalid schema version",
+			devfile: generateDummyDevfile("1.0.0", nil, components, nil, nil, nil),
+			wantErr: []string{invalidSchemaVersionErr},
+		},
+		{
+			name:    "Schema version from options",
+			devfile: generateDummyDevfile("2.2.0", nil, components, nil, nil, nil),
+			options: ValidationOptions{SchemaVersion: "1.0.0"},
+			wantErr: []string{invalidSchemaVersionErr},
+		},
+		{
+			name:      "Variables with schema version 2.0.0",
+			devfile:   generateDummyDevfile("2.0.0", map[string]string{"image": "quay.io/devfile/tools:1.0"}, generateVariableImageComponent(), nil, nil, nil),
+			wantErr:   []string{"variables requires schemaVersion 2.1.0 or later, but the schemaVersion is 2.0.0"},
+			wantImage: "quay.io/devfile/tools:1.0",
+		},
+		{
+			name:      "Variable substitution",
+			devfile:   generateDummyDevfile("2.2.0", map[string]string{"image": "quay.io/devfile/tools:1.0"}, generateVariableImageComponent(), nil, nil, nil),
+			wantImage: "quay.io/devfile/tools:1.0",
+		},
+		{
+			name:        "Invalid variable reference",
+			devfile:     generateDummyDevfile("2.2.0", nil, generateVariableImageComponent(), nil, nil, nil),
+			wantWarning: []string{invalidVariableWarning},
+			wantImage:   "{{image}}",
+		},
+		{
+			name: "Variables referencing variables",
+			devfile: generateDummyDevfile("2.2.0", map[string]string{"registry": "quay.io/devfile", "image": "{{registry}}/tools:1.0"},
+				generateVariableImageComponent(), nil, nil, nil),
+			wantImage: "quay.io/devfile/tools:1.0",
+		},
+		{
+			name: "Invalid variable reference in a variable",
+			devfile: generateDummyDevfile("2.2.0", map[string]string{"image": "{{registry}}/tools:1.0"},
+				generateVariableImageComponent(), nil, nil, nil),
+			wantWarning: []string{"invalid variable references - registry"},
+			wantImage:   "{{registry}}/tools:1.0",
+		},
+		{
+			name: "Variable reference cycle",
+			devfile: generateDummyDevfile("2.2.0", map[string]string{"image": "{{tag}}", "tag": "{{image}}"},
+				generateVariableImageComponent(), nil, nil, nil),
+			wantErr:   []string{"variables reference each other in a cycle - image -> tag -> image"},
+			wantImage: "{{tag}}",
+		},
+		{
+			name:    "External variable sources",
+			devfile: generateDummyDevfile("2.2.0", map[string]string{"image": "quay.io/devfile/tools:1.0"}, generateVariableImageComponent(), nil, nil, nil),
+			options: ValidationOptions{VariableSources: []variables.VariableSource{
+				{Name: "--var", Variables: map[string]string{"image": "quay.io/devfile/tools:2.0"}},
+			}},
+			wantImage: "quay.io/devfile/tools:2.0",
+		},
+		{
+			name:      "Skip variable substitution",
+			devfile:   generateDummyDevfile("2.2.0", map[string]string{"image": "quay.io/devfile/tools:1.0"}, generateVariableImageComponent(), nil, nil, nil),
+			options:   ValidationOptions{SkipVariableSubstitution: true},
+			wantImage: "{{image}}",
+		},
+		{
+			name:    "Image policy checked after variable substitution",
+			devfile: generateDummyDevfile("2.2.0", map[string]string{"image": "quay.io/devfile/tools:latest"}, generateVariableImageComponent(), nil, nil, nil),
+			options: ValidationOptions{ImagePolicy: &ImagePolicy{MutableTag: ErrorSeverity}},
+			wantErr: []string{"the image \"quay.io/devfile/tools:latest\" of component alias1 is untagged or uses the latest tag"},
+		},
+		{
+			name:    "Invalid image reference after variable substitution",
+			devfile: generateDummyDevfile("2.2.0", map[string]string{"image": "quay.io/devfile/tools:"}, generateVariableImageComponent(), nil, nil, nil),
+			wantErr: []string{"the image \"quay.io/devfile/tools:\" of component alias1 is invalid"},
+		},
+		{
+			name: "Multiple errors and warnings: invalid components, commands and dependent projects",
+			devfile: generateDummyDevfile("2.2.0", nil,
+				[]v1alpha2.Component{generateDummyContainerComponent(component, volMounts, nil, nil, v1alpha2.Annotation{}, false)},
+				commands, nil, []v1alpha2.Project{projectWithTwoRemotes}),
+			wantErr:     []string{missingVolumeMountErr, missingCheckoutErr},
+			wantWarning: []string{noDefaultCmdWarning},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := ValidateDevfile(tt.devfile, tt.options)
+
+			if assert.Equal(t, len(tt.wantErr), len(result.Errors), "Error list length should match") {
+				for i := 0; i < len(result.Errors); i++ {
+					assert.Regexp(t, tt.wantErr[i], result.Errors[i].Error(), "Error message should match")
+				}
+			}
+			if assert.Equal(t, len(tt.wantWarning), len(result.Warnings), "Warning list length should match") {
+				for i := 0; i < len(result.Warnings); i++ {
+					assert.Regexp(t, tt.wantWarning[i], result.Warnings[i].Error(), "Warning message should match")
+				}
+			}
+			if tt.wantImage != "" {
+				assert.Equal(t, tt.wantImage, tt.devfile.Components[0].Container.Image, "Container image should match")
+			}
+		})
+	}
+}
+
+func TestValidateDevfileVariableReport(t *testing.T) {
+
+	component := generateDummyContainerComponent("component1", nil, nil, nil, v1alpha2.Annotation{}, false)
+	component.Container.Image = "{{registry}}/{{image}}:{{tag}}"
+	devfile := generateDummyDevfile("2.2.0", map[string]string{"image": "tools", "tag": "1.0"}, []v1alpha2.Component{component}, nil, nil, nil)
+
+	result := ValidateDevfile(devfile, ValidationOptions{VariableSources: []variables.VariableSource{
+		{Name: "--var", Variables: map[string]string{"tag": "2.0"}},
+	}})
+
+	wantInfo := []*Finding{
+		{RuleID: RuleVariableSource, Kind: VariableElement, Key: "image", Path: "/variables/image"},
+		{RuleID: RuleVariableSource, Kind: VariableElement, Key: "tag", Path: "/variables/tag"},
+		{RuleID: RuleUndefinedVariable, Kind: VariableElement, Key: "registry", Path: "/variables/registry"},
+	}
+	wantInfoMessages := []string{
+		"variable image is supplied by the main devfile source",
+		"variable tag is supplied by the --var source",
+		"variable registry is referenced but not defined",
+	}
+	if assert.Equal(t, len(wantInfo), len(result.Info), "Info list length should match") {
+		for i := 0; i < len(result.Info); i++ {
+			assert.Equal(t, wantInfo[i].RuleID, result.Info[i].RuleID, "Finding rule should match")
+			assert.Equal(t, wantInfo[i].Kind, result.Info[i].Kind, "Finding kind should match")
+			assert.Equal(t, wantInfo[i].Key, result.Info[i].Key, "Finding key should match")
+			assert.Equal(t, wantInfo[i].Path, result.Info[i].Path, "Finding path should match")
+			assert.Equal(t, InfoSeverity, result.Info[i].Severity, "Finding severity should match")
+			assert.Equal(t, wantInfoMessages[i], result.Info[i].Error(), "Finding message should match")
+		}
+	}
+	if assert.Equal(t, 1, len(result.Warnings), "Warning list length should match") {
+		assert.Equal(t, "invalid variable references - registry", result.Warnings[0].Error(), "Warning message should match")
+	}
+}
+
+func TestValidateGlobalVariables(t *testing.T) {
+
+	generateVariableSpec := func(image string, devfileVariables map[string]string) *v1alpha2.DevWorkspaceTemplateSpec {
+		component := generateDummyContainerComponent("component1", nil, nil, nil, v1alpha2.Annotation{}, false)
+		component.Container.Image = image
+		return &generateDummyDevfile("2.2.0", devfileVariables, []v1alpha2.Component{component}, nil, nil, nil).DevWorkspaceTemplateSpec
+	}
+
+	tests := []struct {
+		name          string
+		spec          *v1alpha2.DevWorkspaceTemplateSpec
+		sources       []variables.VariableSource
+		wantErr       []*Finding
+		wantWarning   []*Finding
+		wantImage     string
+		wantVariables map[string]string
+	}{
+		{
+			name:          "Variable replaced with the value of the first source",
+			spec:          generateVariableSpec("{{image}}", map[string]string{"image": "quay.io/devfile/tools:1.0"}),
+			sources:       []variables.VariableSource{{Name: "--var", Variables: map[string]string{"image": "quay.io/devfile/tools:2.0"}}},
+			wantImage:     "quay.io/devfile/tools:2.0",
+			wantVariables: map[string]string{"image": "quay.io/devfile/tools:1.0"},
+		},
+		{
+			name: "Invalid variable reference",
+			spec: generateVariableSpec("{{image}}", nil),
+			wantWarning: []*Finding{
+				{RuleID: RuleVariableReference, Kind: ComponentElement, Key: "component1", Path: "/components[name=component1]"},
+			},
+			wantImage: "{{image}}",
+		},
+		{
+			name: "Variable reference cycle",
+			spec: generateVariableSpec("{{image}}", map[string]string{"image": "{{image}}"}),
+			wantErr: []*Finding{
+				{RuleID: RuleVariableCycle, Kind: VariableElement, Key: "image", Path: "/variables/image"},
+			},
+			wantImage:     "{{image}}",
+			wantVariables: map[string]string{"image": "{{image}}"},
+		},
+		{
+			name: "Replaced annotation key colliding with another key",
+			spec: func() *v1alpha2.DevWorkspaceTemplateSpec {
+				spec := generateVariableSpec("{{image}}", map[string]string{"image": "quay.io/devfile/tools:1.0", "key": "app"})
+				spec.Components[0].Container.Annotation = &v1alpha2.Annotation{Service: map[string]string{"{{key}}": "a", "app": "b"}}
+				return spec
+			}(),
+			wantErr: []*Finding{
+				{RuleID: RuleVariableKeyCollision, Kind: ComponentElement, Key: "component1", Path: "/components[name=component1]"},
+			},
+			wantImage:     "quay.io/devfile/tools:1.0",
+			wantVariables: map[string]string{"image": "quay.io/devfile/tools:1.0", "key": "app"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sources := append(tt.sources, variables.DevfileVariableSources(tt.spec)...)
+			result := ValidateGlobalVariables(tt.spec, sources)
+
+			assertFindings := func(want, got []*Finding, listName string) {
+				if assert.Equal(t, len(want), len(got), "%s list length should match", listName) {
+					for i := 0; i < len(got); i++ {
+						assert.Equal(t, want[i].RuleID, got[i].RuleID, "Finding rule should match")
+						assert.Equal(t, want[i].Kind, got[i].Kind, "Finding kind should match")
+						assert.Equal(t, want[i].Key, got[i].Key, "Finding key should match")
+						assert.Equal(t, want[i].Path, got[i].Path, "Finding path should match")
+					}
+				}
+			}
+			assertFindings(tt.wantErr, result.Errors, "Error")
+			assertFindings(tt.wantWarning, result.Warnings, "Warning")
+			assert.Equal(t, tt.wantImage, tt.spec.Components[0].Container.Image, "Container image should match")
+			assert.Equal(t, tt.wantVariables, tt.spec.Variables, "Devfile variables should be unchanged")
+		})
+	}
+
+	assert.Equal(t, ValidationResult{}, ValidateGlobalVariables(nil, nil), "Validation result of a nil spec should be empty")
+}
+
+func TestValidateDevWorkspaceTemplateSpecUnescapeVariableReferences(t *testing.T) {
+
+	readFileToStruct := func(path string, into interface{}) {
+		bytes, err := ioutil.ReadFile(path)
+		if err != nil {
+			t.Fatalf("Failed to read test file from %s: %s", path, err.Error())
+		}
+		if err = yaml.Unmarshal(bytes, into); err != nil {
+			t.Fatalf("Failed to unmarshal file into struct: %s", err.Error())
+		}
+	}
+
+	tests := []struct {
+		name           string
+		options        ValidationOptions
+		wantOutputPath string
+	}{
+		{
+			name:           "Escaped variable references kept by default",
+			wantOutputPath: "variables/test-fixtures/all/devfile-good-output.yaml",
+		},
+		{
+			name:           "Escaped variable references unescaped after the validation",
+			options:        ValidationOptions{UnescapeVariableReferences: true},
+			wantOutputPath: "variables/test-fixtures/all/devfile-good-unescaped-output.yaml",
+		},
+		{
+			name:           "Escaped variable references kept with the variable substitution skipped",
+			options:        ValidationOptions{UnescapeVariableReferences: true, SkipVariableSubstitution: true},
+			wantOutputPath: "variables/test-fixtures/all/devfile-good.yaml",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			testDWT := v1alpha2.DevWorkspaceTemplateSpec{}
+			readFileToStruct("variables/test-fixtures/all/devfile-good.yaml", &testDWT)
+
+			ValidateDevWorkspaceTemplateSpec(&testDWT, tt.options)
+
+			expectedDWT := v1alpha2.DevWorkspaceTemplateSpec{}
+			readFileToStruct(tt.wantOutputPath, &expectedDWT)
+			assert.Equal(t, expectedDWT, testDWT, "The two values should be the same.")
+		})
+	}
+
+	devfile := generateDummyDevfile("2.2.0", map[string]string{"tag": "xyz"}, nil,
+		[]v1alpha2.Command{generateDummyExecCommand("run", "runtime", nil)}, nil, nil)
+	devfile.Commands[0].Exec.CommandLine = `test-{{tag}} && docker inspect --format \{{.State}}`
+	ValidateDevfile(devfile, ValidationOptions{UnescapeVariableReferences: true})
+	assert.Equal(t, "test-xyz && docker inspect --format {{.State}}", devfile.Commands[0].Exec.CommandLine,
+		"The escaped variable references should be unescaped")
+}
+
+func TestValidateDevWorkspaceTemplateSpec(t *testing.T) {
+
+	invalidURIErr := ".*invalid URI for request"
+
+	tests := []struct {
+		name    string
+		parent  *v1alpha2.Parent
+		wantErr []string
+	}{
+		{
+			name: "Valid parent reference",
+			parent: &v1alpha2.Parent{
+				ImportReference: v1alpha2.ImportReference{
+					ImportReferenceUnion: v1alpha2.ImportReferenceUnion{
+						Id: "nodejs",
+					},
+					RegistryUrl: "https://registry.devfile.io",
+				},
+			},
+		},
+		{
+			name: "Invalid parent uri",
+			parent: &v1alpha2.Parent{
+				ImportReference: v1alpha2.ImportReference{
+					ImportReferenceUnion: v1alpha2.ImportReferenceUnion{
+						Uri: "http//wronguri",
+					},
+				},
+			},
+			wantErr: []string{invalidURIErr},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := ValidateDevWorkspaceTemplateSpec(&v1alpha2.DevWorkspaceTemplateSpec{Parent: tt.parent}, ValidationOptions{})
+
+			if assert.Equal(t, len(tt.wantErr), len(result.Errors), "Error list length should match") {
+				for i := 0; i < len(result.Errors); i++ {
+					assert.Regexp(t, tt.wantErr[i], result.Errors[i].Error(), "Error message should match")
+				}
+			}
+		})
+	}
+}
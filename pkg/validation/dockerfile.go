@@ -0,0 +1,108 @@
+//
+//
+// Copyright Red Hat
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validation
+
+import (
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/devfile/api/v2/pkg/apis/workspaces/v1alpha2"
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+// projectSourceReference is the reference to the project source a dockerfile build context can start with
+var projectSourceReference = fmt.Sprintf("${%s}", EnvProjectsSrc)
+
+// validateDockerfileImage checks the dockerfile of the image component
+// 1. the dockerfile args must be in the KEY=VALUE form
+// 2. the build context must be a relative path or start with ${PROJECT_SOURCE}
+// 3. the devfile registry id must be a valid id, and should come with a registry url (warning)
+// 4. the git file location must be relative to the repository and must not escape it
+//
+// The values still referencing a global variable are skipped, the invalid variable reference is reported on its own.
+func validateDockerfileImage(component v1alpha2.Component) (result ValidationResult) {
+	dockerfile := component.Image.Dockerfile
+	dockerfilePath := fmt.Sprintf("%s/dockerfile", componentTypePath(component))
+
+	for _, arg := range dockerfile.Args {
+		if unresolvedVariableRegex.MatchString(arg) {
+			continue
+		}
+		if key, _, found := strings.Cut(arg, "="); !found || key == "" || strings.ContainsAny(key, " \t") {
+			result.addError(newFinding(RuleDockerfileArg, &InvalidDockerfileArgError{componentName: component.Name, arg: arg},
+				ComponentElement, component.Name, fmt.Sprintf("%s/args", dockerfilePath), component.Attributes))
+		}
+	}
+
+	if buildContext := dockerfile.BuildContext; buildContext != "" && !unresolvedVariableRegex.MatchString(buildContext) {
+		if !isValidBuildContext(buildContext) {
+			buildContextErr := &InvalidBuildContextError{componentName: component.Name, buildContext: buildContext}
+			result.addError(newFinding(RuleDockerfileBuildContext, buildContextErr,
+				ComponentElement, component.Name, fmt.Sprintf("%s/buildContext", dockerfilePath), component.Attributes))
+		}
+	}
+
+	if registry := dockerfile.DevfileRegistry; registry != nil {
+		registryPath := fmt.Sprintf("%s/devfileRegistry", dockerfilePath)
+		if !unresolvedVariableRegex.MatchString(registry.Id) && len(validation.IsDNS1123Label(registry.Id)) > 0 {
+			result.addError(newFinding(RuleDockerfileRegistryId, &InvalidDockerfileRegistryIdError{componentName: component.Name, id: registry.Id},
+				ComponentElement, component.Name, fmt.Sprintf("%s/id", registryPath), component.Attributes))
+		}
+		if registry.RegistryUrl == "" {
+			result.addWarning(newFinding(RuleDockerfileRegistryUrl, &MissingDockerfileRegistryUrlWarning{componentName: component.Name, id: registry.Id},
+				ComponentElement, component.Name, fmt.Sprintf("%s/registryUrl", registryPath), component.Attributes))
+		} else {
+			result.Merge(validateURIField(registry.RegistryUrl,
+				ComponentElement, component.Name, fmt.Sprintf("%s/registryUrl", registryPath), component.Attributes))
+		}
+	}
+
+	if git := dockerfile.Git; git != nil && git.FileLocation != "" && !unresolvedVariableRegex.MatchString(git.FileLocation) {
+		if reason := validateDockerfileLocation(git.FileLocation); reason != "" {
+			locationErr := &InvalidDockerfileLocationError{componentName: component.Name, fileLocation: git.FileLocation, reason: reason}
+			result.addError(newFinding(RuleDockerfileLocation, locationErr,
+				ComponentElement, component.Name, fmt.Sprintf("%s/git/fileLocation", dockerfilePath), component.Attributes))
+		}
+	}
+
+	return result
+}
+
+// isValidBuildContext returns true if the build context is a relative path, or a path starting with ${PROJECT_SOURCE}.
+// An absolute path, or a path starting with another env reference, depends on the container the image is built in
+func isValidBuildContext(buildContext string) bool {
+	if buildContext == projectSourceReference || strings.HasPrefix(buildContext, projectSourceReference+"/") {
+		return true
+	}
+	return !path.IsAbs(buildContext) && !strings.HasPrefix(buildContext, "$")
+}
+
+// validateDockerfileLocation checks the dockerfile location is a relative path which does not escape the git repository,
+// it returns the reason the location is invalid or an empty string if it is valid
+func validateDockerfileLocation(fileLocation string) string {
+	cleanPath := path.Clean(fileLocation)
+	switch {
+	case path.IsAbs(fileLocation):
+		return "the path must be relative to the root of the repository"
+	case cleanPath == ".." || strings.HasPrefix(cleanPath, "../"):
+		return "the path must not escape the repository"
+	case cleanPath == ".":
+		return "the path must be a file of the repository"
+	}
+	return ""
+}
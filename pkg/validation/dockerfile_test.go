@@ -0,0 +1,135 @@
+//
+//
+// Copyright Red Hat
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validation
+
+import (
+	"testing"
+
+	"github.com/devfile/api/v2/pkg/apis/workspaces/v1alpha2"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateDockerfileImage(t *testing.T) {
+
+	// generateDockerfileImageComponent returns a dummy image component with the given dockerfile for testing
+	generateDockerfileImageComponent := func(src v1alpha2.DockerfileSrc, buildContext string, args ...string) v1alpha2.Component {
+		component := generateDummyImageComponent("name1", src)
+		component.Image.Dockerfile.BuildContext = buildContext
+		component.Image.Dockerfile.Args = args
+		return component
+	}
+
+	uriSrc := v1alpha2.DockerfileSrc{Uri: "Dockerfile"}
+	registrySrc := func(id, registryUrl string) v1alpha2.DockerfileSrc {
+		return v1alpha2.DockerfileSrc{DevfileRegistry: &v1alpha2.DockerfileDevfileRegistrySource{Id: id, RegistryUrl: registryUrl}}
+	}
+	gitSrc := func(fileLocation string) v1alpha2.DockerfileSrc {
+		return v1alpha2.DockerfileSrc{
+			Git: &v1alpha2.DockerfileGitProjectSource{
+				GitProjectSource: v1alpha2.GitProjectSource{
+					GitLikeProjectSource: v1alpha2.GitLikeProjectSource{
+						Remotes: map[string]string{"origin": "url"},
+					},
+				},
+				FileLocation: fileLocation,
+			},
+		}
+	}
+
+	invalidArgErr := "the dockerfile arg \".*\" of image component name1 must be in the KEY=VALUE form"
+	invalidBuildContextErr := "the buildContext .* of image component name1 must be a relative path or start with \\${PROJECT_SOURCE}"
+	invalidRegistryIdErr := "the devfile registry id \"Java_Maven\" of image component name1 must be a lowercase alphanumeric id.*"
+	invalidRegistryUrlErr := "parse \"http//registry\": invalid URI for request"
+	missingRegistryUrlWarning := "the devfile registry id java-maven of image component name1 should come with a registryUrl.*"
+	escapingLocationErr := "the dockerfile fileLocation ../Dockerfile of image component name1 is invalid: the path must not escape the repository"
+	absoluteLocationErr := "the dockerfile fileLocation /Dockerfile of image component name1 is invalid: the path must be relative to the root of the repository"
+
+	tests := []struct {
+		name        string
+		component   v1alpha2.Component
+		wantErr     []string
+		wantWarning []string
+	}{
+		{
+			name:      "Valid dockerfile image with relative build context and args",
+			component: generateDockerfileImageComponent(uriSrc, "./app", "GO_VERSION=1.24", "EMPTY=", "{{args}}"),
+		},
+		{
+			name:      "Valid dockerfile image with project source build context",
+			component: generateDockerfileImageComponent(uriSrc, "${PROJECT_SOURCE}"),
+		},
+		{
+			name:      "Invalid dockerfile args",
+			component: generateDockerfileImageComponent(uriSrc, "", "GO_VERSION", "=1.24", "GO VERSION=1.24"),
+			wantErr:   []string{invalidArgErr, invalidArgErr, invalidArgErr},
+		},
+		{
+			name:      "Invalid absolute build context",
+			component: generateDockerfileImageComponent(uriSrc, "/projects/app"),
+			wantErr:   []string{invalidBuildContextErr},
+		},
+		{
+			name:      "Invalid build context with another env reference",
+			component: generateDockerfileImageComponent(uriSrc, "${HOME}/app"),
+			wantErr:   []string{invalidBuildContextErr},
+		},
+		{
+			name:      "Valid devfile registry source",
+			component: generateDockerfileImageComponent(registrySrc("java-maven", "https://registry.devfile.io"), ""),
+		},
+		{
+			name:      "Invalid devfile registry id and url",
+			component: generateDockerfileImageComponent(registrySrc("Java_Maven", "http//registry"), ""),
+			wantErr:   []string{invalidRegistryIdErr, invalidRegistryUrlErr},
+		},
+		{
+			name:        "Devfile registry source without registry url",
+			component:   generateDockerfileImageComponent(registrySrc("java-maven", ""), ""),
+			wantWarning: []string{missingRegistryUrlWarning},
+		},
+		{
+			name:      "Valid git file location",
+			component: generateDockerfileImageComponent(gitSrc("docker/Dockerfile"), ""),
+		},
+		{
+			name:      "Git file location escaping the repository",
+			component: generateDockerfileImageComponent(gitSrc("../Dockerfile"), ""),
+			wantErr:   []string{escapingLocationErr},
+		},
+		{
+			name:      "Absolute git file location",
+			component: generateDockerfileImageComponent(gitSrc("/Dockerfile"), ""),
+			wantErr:   []string{absoluteLocationErr},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := validateDockerfileImage(tt.component)
+
+			if assert.Equal(t, len(tt.wantErr), len(result.Errors), "Error list length should match") {
+				for i := 0; i < len(result.Errors); i++ {
+					assert.Regexp(t, tt.wantErr[i], result.Errors[i].Error(), "Error message should match")
+				}
+			}
+			if assert.Equal(t, len(tt.wantWarning), len(result.Warnings), "Warning list length should match") {
+				for i := 0; i < len(result.Warnings); i++ {
+					assert.Regexp(t, tt.wantWarning[i], result.Warnings[i].Error(), "Warning message should match")
+				}
+			}
+		})
+	}
+}
@@ -16,40 +16,182 @@
 
 package validation
 
-import "github.com/devfile/api/v2/pkg/apis/workspaces/v1alpha2"
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/devfile/api/v2/pkg/apis/workspaces/v1alpha2"
+	"k8s.io/apimachinery/pkg/util/validation"
+)
 
 // validateEndpoints checks if
 //  1. all the endpoint names are unique across components
-//  2. endpoint port are unique across component containers
-//     ie; two component containers cannot have the same target port but two endpoints
+//  2. endpoint port are unique across the component containers of a pod
+//     ie; two component containers running in the same pod cannot have the same target port but two endpoints
 //     in a single component container can have the same target port
-func validateEndpoints(endpoints []v1alpha2.Endpoint, processedEndPointPort map[int]bool, processedEndPointName map[string]bool) (errList []error) {
-	currentComponentEndPointPort := make(map[int]bool)
-
-	errList = validateDuplicatedName(endpoints, processedEndPointName, currentComponentEndPointPort)
-	portErrorList := validateDuplicatedPort(processedEndPointPort, currentComponentEndPointPort)
+//
+// processedEndPointPort holds the component names of the target ports already processed in the pod of the component
+func validateEndpoints(componentName string, endpoints []v1alpha2.Endpoint, processedEndPointPort map[int]string, processedEndPointName map[string]bool) (errList []error) {
+	errList = validateDuplicatedName(endpoints, processedEndPointName)
+	portErrorList := validateDuplicatedPort(componentName, endpoints, processedEndPointPort)
 	errList = append(errList, portErrorList...)
 
 	return errList
 }
 
-func validateDuplicatedName(endpoints []v1alpha2.Endpoint, processedEndPointName map[string]bool, currentComponentEndPointPort map[int]bool) (errList []error) {
+func validateDuplicatedName(endpoints []v1alpha2.Endpoint, processedEndPointName map[string]bool) (errList []error) {
 	for _, endPoint := range endpoints {
 		if _, ok := processedEndPointName[endPoint.Name]; ok {
 			errList = append(errList, &InvalidEndpointError{name: endPoint.Name})
 		}
 		processedEndPointName[endPoint.Name] = true
-		currentComponentEndPointPort[endPoint.TargetPort] = true
 	}
 	return errList
 }
 
-func validateDuplicatedPort(processedEndPointPort map[int]bool, currentComponentEndPointPort map[int]bool) (errList []error) {
-	for targetPort := range currentComponentEndPointPort {
-		if _, ok := processedEndPointPort[targetPort]; ok {
-			errList = append(errList, &InvalidEndpointError{port: targetPort})
+func validateDuplicatedPort(componentName string, endpoints []v1alpha2.Endpoint, processedEndPointPort map[int]string) (errList []error) {
+	currentComponentEndPointPort := make(map[int]bool)
+	for _, endPoint := range endpoints {
+		if currentComponentEndPointPort[endPoint.TargetPort] {
+			continue
+		}
+		currentComponentEndPointPort[endPoint.TargetPort] = true
+
+		if processedComponentName, ok := processedEndPointPort[endPoint.TargetPort]; ok {
+			errList = append(errList, &InvalidEndpointError{port: endPoint.TargetPort, componentName: componentName, conflictingComponentName: processedComponentName})
+			continue
 		}
-		processedEndPointPort[targetPort] = true
+		processedEndPointPort[endPoint.TargetPort] = componentName
 	}
 	return errList
 }
+
+// validateEndpointFields checks if
+//  1. a secure endpoint does not use the http, ws, tcp or udp protocol
+//  2. the endpoint target port is between 1 and 65535
+//  3. the endpoint path is a valid URL path, without scheme, host, query or fragment
+//  4. the endpoint annotation keys are valid Kubernetes annotation keys
+//  5. an endpoint with exposure none has no ingress or route annotation, reported as a warning since the annotations are ignored
+func validateEndpointFields(component v1alpha2.Component, endpoints []v1alpha2.Endpoint) (result ValidationResult) {
+	endpointsPath := fmt.Sprintf("%s/endpoints", componentTypePath(component))
+
+	for _, endpoint := range endpoints {
+		endpointPath := fmt.Sprintf("%s[name=%s]", endpointsPath, endpoint.Name)
+
+		if endpoint.Secure != nil && *endpoint.Secure {
+			switch endpoint.Protocol {
+			case v1alpha2.HTTPEndpointProtocol, v1alpha2.WSEndpointProtocol, v1alpha2.TCPEndpointProtocol, v1alpha2.UDPEndpointProtocol:
+				protocolErr := &InsecureEndpointProtocolError{endpointName: endpoint.Name, componentName: component.Name, protocol: endpoint.Protocol}
+				result.addError(newFinding(RuleEndpointSecureProtocol, protocolErr,
+					EndpointElement, endpoint.Name, fmt.Sprintf("%s/protocol", endpointPath), component.Attributes))
+			}
+		}
+
+		if len(validation.IsValidPortNum(endpoint.TargetPort)) > 0 {
+			targetPortErr := &InvalidEndpointTargetPortError{endpointName: endpoint.Name, componentName: component.Name, targetPort: endpoint.TargetPort}
+			result.addError(newFinding(RuleEndpointTargetPort, targetPortErr,
+				EndpointElement, endpoint.Name, fmt.Sprintf("%s/targetPort", endpointPath), component.Attributes))
+		}
+
+		if endpoint.Path != "" {
+			if reason := validateEndpointPath(endpoint.Path); reason != "" {
+				pathErr := &InvalidEndpointPathError{endpointName: endpoint.Name, componentName: component.Name, path: endpoint.Path, reason: reason}
+				result.addError(newFinding(RuleEndpointPath, pathErr,
+					EndpointElement, endpoint.Name, fmt.Sprintf("%s/path", endpointPath), component.Attributes))
+			}
+		}
+
+		for _, key := range getSortedKeys(endpoint.Annotations) {
+			// annotation keys are validated as Kubernetes does for the metadata annotations
+			if reasons := validation.IsQualifiedName(strings.ToLower(key)); len(reasons) > 0 {
+				annotationKeyErr := &InvalidEndpointAnnotationKeyError{endpointName: endpoint.Name, componentName: component.Name, key: key, reasons: reasons}
+				result.addError(newFinding(RuleEndpointAnnotationKey, annotationKeyErr,
+					AnnotationElement, key, fmt.Sprintf("%s/annotation[%s]", endpointPath, key), component.Attributes))
+			}
+		}
+
+		if endpoint.Exposure == v1alpha2.NoneEndpointExposure {
+			var ingressKeys []string
+			for _, key := range getSortedKeys(endpoint.Annotations) {
+				if isIngressAnnotationKey(key) {
+					ingressKeys = append(ingressKeys, key)
+				}
+			}
+			if len(ingressKeys) == 0 {
+				continue
+			}
+			exposureErr := &UnexposedEndpointAnnotationError{endpointName: endpoint.Name, componentName: component.Name, keys: ingressKeys}
+			result.addWarning(newFinding(RuleEndpointExposure, exposureErr,
+				EndpointElement, endpoint.Name, fmt.Sprintf("%s/annotation", endpointPath), component.Attributes))
+		}
+	}
+
+	return result
+}
+
+// ingressAnnotationDomains are the domains of the ingress and route annotation keys, an annotation key of a subdomain,
+// e.g. nginx.ingress.kubernetes.io/rewrite-target, belongs to the domain
+var ingressAnnotationDomains = []string{"ingress.kubernetes.io", "route.openshift.io", "router.openshift.io"}
+
+// isIngressAnnotationKey returns true if the annotation key configures the ingress or route exposing the endpoint,
+// ie; the key prefix belongs to an ingress or route domain, or the key is a kubernetes.io/ingress.* key
+func isIngressAnnotationKey(key string) bool {
+	prefix, name, found := strings.Cut(strings.ToLower(key), "/")
+	if !found {
+		return false
+	}
+	if prefix == "kubernetes.io" {
+		return strings.HasPrefix(name, "ingress.")
+	}
+	for _, domain := range ingressAnnotationDomains {
+		if prefix == domain || strings.HasSuffix(prefix, "."+domain) {
+			return true
+		}
+	}
+	return false
+}
+
+// validateEndpointPath returns the reason the endpoint path is not a valid URL path, or an empty string if it is valid
+func validateEndpointPath(path string) string {
+	if strings.ContainsAny(path, " \t\n") {
+		return "the path must not contain whitespaces"
+	}
+	parsedPath, err := url.Parse(path)
+	if err != nil {
+		return err.Error()
+	}
+	if parsedPath.Scheme != "" || parsedPath.Host != "" || parsedPath.Opaque != "" {
+		return "the path must not contain a scheme or a host"
+	}
+	if parsedPath.RawQuery != "" || parsedPath.Fragment != "" || strings.ContainsAny(path, "?#") {
+		return "the path must not contain a query or a fragment"
+	}
+	return ""
+}
+
+// newEndpointFinding returns a finding for the endpoint validation error reported on the given component endpoints,
+// an error other than InvalidEndpointError is reported on the component endpoints
+func newEndpointFinding(component v1alpha2.Component, endpoints []v1alpha2.Endpoint, endpointErr error) *Finding {
+	endpointsPath := fmt.Sprintf("%s/endpoints", componentTypePath(component))
+
+	var invalidEndpointErr *InvalidEndpointError
+	if !errors.As(endpointErr, &invalidEndpointErr) {
+		return newFinding(RuleEndpoint, endpointErr, ComponentElement, component.Name, endpointsPath, component.Attributes)
+	}
+	if invalidEndpointErr.name != "" {
+		return newFinding(RuleEndpointDuplicateName, endpointErr, EndpointElement, invalidEndpointErr.name,
+			fmt.Sprintf("%s[name=%s]", endpointsPath, invalidEndpointErr.name), component.Attributes)
+	}
+
+	var name string
+	for _, endpoint := range endpoints {
+		if endpoint.TargetPort == invalidEndpointErr.port {
+			name = endpoint.Name
+			break
+		}
+	}
+	return newFinding(RuleEndpointDuplicatePort, endpointErr, EndpointElement, name,
+		fmt.Sprintf("%s[name=%s]/targetPort", endpointsPath, name), component.Attributes)
+}
@@ -17,6 +17,7 @@
 package validation
 
 import (
+	"fmt"
 	"testing"
 
 	"github.com/devfile/api/v2/pkg/apis/workspaces/v1alpha2"
@@ -26,13 +27,13 @@ import (
 func TestValidateEndpoints(t *testing.T) {
 
 	duplicateNameErr := "multiple endpoint entries with same name"
-	duplicatePortErr := "devfile contains multiple containers with same endpoint targetPort"
+	duplicatePortErr := "devfile contains multiple containers with same endpoint targetPort: 808[01], used by both container components tools and runtime in the same pod"
 
 	tests := []struct {
 		name                  string
 		endpoints             []v1alpha2.Endpoint
 		processedEndpointName map[string]bool
-		processedEndpointPort map[int]bool
+		processedEndpointPort map[int]string
 		wantErr               []string
 	}{
 		{
@@ -42,7 +43,7 @@ func TestValidateEndpoints(t *testing.T) {
 				generateDummyEndpoint("url1", 8081),
 			},
 			processedEndpointName: map[string]bool{},
-			processedEndpointPort: map[int]bool{},
+			processedEndpointPort: map[int]string{},
 			wantErr:               []string{duplicateNameErr},
 		},
 		{
@@ -53,7 +54,7 @@ func TestValidateEndpoints(t *testing.T) {
 			processedEndpointName: map[string]bool{
 				"url1": true,
 			},
-			processedEndpointPort: map[int]bool{},
+			processedEndpointPort: map[int]string{},
 			wantErr:               []string{duplicateNameErr},
 		},
 		{
@@ -63,7 +64,7 @@ func TestValidateEndpoints(t *testing.T) {
 				generateDummyEndpoint("url2", 8080),
 			},
 			processedEndpointName: map[string]bool{},
-			processedEndpointPort: map[int]bool{},
+			processedEndpointPort: map[int]string{},
 		},
 		{
 			name: "Duplicate endpoint port across components",
@@ -72,8 +73,8 @@ func TestValidateEndpoints(t *testing.T) {
 				generateDummyEndpoint("url2", 8081),
 			},
 			processedEndpointName: map[string]bool{},
-			processedEndpointPort: map[int]bool{
-				8080: true,
+			processedEndpointPort: map[int]string{
+				8080: "tools",
 			},
 			wantErr: []string{duplicatePortErr},
 		},
@@ -86,16 +87,16 @@ func TestValidateEndpoints(t *testing.T) {
 			processedEndpointName: map[string]bool{
 				"url1": true,
 			},
-			processedEndpointPort: map[int]bool{
-				8080: true,
-				8081: true,
+			processedEndpointPort: map[int]string{
+				8080: "tools",
+				8081: "tools",
 			},
 			wantErr: []string{duplicateNameErr, duplicatePortErr, duplicatePortErr},
 		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			err := validateEndpoints(tt.endpoints, tt.processedEndpointPort, tt.processedEndpointName)
+			err := validateEndpoints("runtime", tt.endpoints, tt.processedEndpointPort, tt.processedEndpointName)
 
 			if tt.wantErr != nil {
 				if assert.Equal(t, len(tt.wantErr), len(err), "Error list length should match") {
@@ -111,6 +112,183 @@ func TestValidateEndpoints(t *testing.T) {
 
 }
 
+func TestValidateEndpointFields(t *testing.T) {
+
+	secure := true
+
+	secureProtocolErr := "endpoint url1 of component component1 is secure but uses the http protocol"
+	targetPortErr := "endpoint url1 of component component1 has an invalid targetPort .*, the targetPort must be between 1 and 65535"
+	pathSchemeErr := "endpoint url1 of component component1 has an invalid path .*: the path must not contain a scheme or a host"
+	pathQueryErr := "endpoint url1 of component component1 has an invalid path .*: the path must not contain a query or a fragment"
+	pathWhitespaceErr := "endpoint url1 of component component1 has an invalid path .*: the path must not contain whitespaces"
+	annotationKeyErr := "endpoint url1 of component component1 has an invalid annotation key \"invalid key\""
+	exposureWarning := "endpoint url1 of component component1 has exposure none, its ingress or route annotations " +
+		"haproxy.router.openshift.io/timeout, kubernetes.io/ingress.class, nginx.ingress.kubernetes.io/rewrite-target are ignored"
+
+	tests := []struct {
+		name        string
+		endpoint    v1alpha2.Endpoint
+		wantErr     []string
+		wantWarning []string
+	}{
+		{
+			name: "Valid endpoint",
+			endpoint: v1alpha2.Endpoint{
+				Name:        "url1",
+				TargetPort:  8080,
+				Protocol:    v1alpha2.HTTPSEndpointProtocol,
+				Secure:      &secure,
+				Path:        "/api/v1",
+				Annotations: map[string]string{"nginx.ingress.kubernetes.io/rewrite-target": "/"},
+			},
+		},
+		{
+			name:     "Secure endpoint with http protocol",
+			endpoint: v1alpha2.Endpoint{Name: "url1", TargetPort: 8080, Protocol: v1alpha2.HTTPEndpointProtocol, Secure: &secure},
+			wantErr:  []string{secureProtocolErr},
+		},
+		{
+			name:     "Secure endpoint without protocol",
+			endpoint: v1alpha2.Endpoint{Name: "url1", TargetPort: 8080, Secure: &secure},
+		},
+		{
+			name:     "Target port 0",
+			endpoint: generateDummyEndpoint("url1", 0),
+			wantErr:  []string{targetPortErr},
+		},
+		{
+			name:     "Target port greater than 65535",
+			endpoint: generateDummyEndpoint("url1", 65536),
+			wantErr:  []string{targetPortErr},
+		},
+		{
+			name:     "Path with a scheme and a host",
+			endpoint: v1alpha2.Endpoint{Name: "url1", TargetPort: 8080, Path: "http://example.com/api"},
+			wantErr:  []string{pathSchemeErr},
+		},
+		{
+			name:     "Path with a query",
+			endpoint: v1alpha2.Endpoint{Name: "url1", TargetPort: 8080, Path: "/api?debug=true"},
+			wantErr:  []string{pathQueryErr},
+		},
+		{
+			name:     "Path with whitespaces",
+			endpoint: v1alpha2.Endpoint{Name: "url1", TargetPort: 8080, Path: "/my api"},
+			wantErr:  []string{pathWhitespaceErr},
+		},
+		{
+			name:     "Invalid annotation key",
+			endpoint: v1alpha2.Endpoint{Name: "url1", TargetPort: 8080, Annotations: map[string]string{"invalid key": "value", "valid-key": "value"}},
+			wantErr:  []string{annotationKeyErr},
+		},
+		{
+			name: "Ingress and route annotations with exposure none",
+			endpoint: v1alpha2.Endpoint{
+				Name:       "url1",
+				TargetPort: 8080,
+				Exposure:   v1alpha2.NoneEndpointExposure,
+				Annotations: map[string]string{
+					"nginx.ingress.kubernetes.io/rewrite-target": "/",
+					"haproxy.router.openshift.io/timeout":        "60s",
+					"kubernetes.io/ingress.class":                "nginx",
+					"valid-key":                                  "value",
+				},
+			},
+			wantWarning: []string{exposureWarning},
+		},
+		{
+			name: "Unrelated annotations with exposure none",
+			endpoint: v1alpha2.Endpoint{
+				Name:        "url1",
+				TargetPort:  8080,
+				Exposure:    v1alpha2.NoneEndpointExposure,
+				Annotations: map[string]string{"valid-key": "value", "example.com/owner": "team"},
+			},
+		},
+		{
+			name:     "Multiple errors: secure endpoint with tcp protocol, invalid target port",
+			endpoint: v1alpha2.Endpoint{Name: "url1", TargetPort: -1, Protocol: v1alpha2.TCPEndpointProtocol, Secure: &secure},
+			wantErr:  []string{"endpoint url1 of component component1 is secure but uses the tcp protocol", targetPortErr},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			component := generateDummyContainerComponent("component1", nil, []v1alpha2.Endpoint{tt.endpoint}, nil, v1alpha2.Annotation{}, false)
+			result := validateEndpointFields(component, component.Container.Endpoints)
+
+			if assert.Equal(t, len(tt.wantErr), len(result.Errors), "Error list length should match") {
+				for i := 0; i < len(result.Errors); i++ {
+					assert.Regexp(t, tt.wantErr[i], result.Errors[i].Error(), "Error message should match")
+				}
+			}
+			if assert.Equal(t, len(tt.wantWarning), len(result.Warnings), "Warning list length should match") {
+				for i := 0; i < len(result.Warnings); i++ {
+					assert.Regexp(t, tt.wantWarning[i], result.Warnings[i].Error(), "Warning message should match")
+				}
+			}
+		})
+	}
+}
+
+func TestNewEndpointFinding(t *testing.T) {
+
+	endpoints := []v1alpha2.Endpoint{generateDummyEndpoint("url1", 8080)}
+	component := generateDummyContainerComponent("component1", nil, endpoints, nil, v1alpha2.Annotation{}, false)
+
+	tests := []struct {
+		name     string
+		err      error
+		wantRule RuleID
+		wantKind ElementKind
+		wantKey  string
+		wantPath string
+	}{
+		{
+			name:     "Duplicate endpoint name",
+			err:      &InvalidEndpointError{name: "url1", componentName: "component1"},
+			wantRule: RuleEndpointDuplicateName,
+			wantKind: EndpointElement,
+			wantKey:  "url1",
+			wantPath: "/components[name=component1]/container/endpoints[name=url1]",
+		},
+		{
+			name:     "Duplicate endpoint port",
+			err:      &InvalidEndpointError{port: 8080, componentName: "component1"},
+			wantRule: RuleEndpointDuplicatePort,
+			wantKind: EndpointElement,
+			wantKey:  "url1",
+			wantPath: "/components[name=component1]/container/endpoints[name=url1]/targetPort",
+		},
+		{
+			name:     "Wrapped endpoint error",
+			err:      fmt.Errorf("wrapped: %w", &InvalidEndpointError{name: "url1", componentName: "component1"}),
+			wantRule: RuleEndpointDuplicateName,
+			wantKind: EndpointElement,
+			wantKey:  "url1",
+			wantPath: "/components[name=component1]/container/endpoints[name=url1]",
+		},
+		{
+			name:     "Other endpoint error",
+			err:      fmt.Errorf("unexpected endpoint error"),
+			wantRule: RuleEndpoint,
+			wantKind: ComponentElement,
+			wantKey:  "component1",
+			wantPath: "/components[name=component1]/container/endpoints",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			finding := newEndpointFinding(component, endpoints, tt.err)
+
+			assert.Equal(t, tt.wantRule, finding.RuleID, "Finding rule should match")
+			assert.Equal(t, tt.wantKind, finding.Kind, "Finding kind should match")
+			assert.Equal(t, tt.wantKey, finding.Key, "Finding key should match")
+			assert.Equal(t, tt.wantPath, finding.Path, "Finding path should match")
+			assert.Equal(t, tt.err, finding.Err, "Finding error should match")
+		})
+	}
+}
+
 func generateDummyEndpoint(name string, port int) v1alpha2.Endpoint {
 	return v1alpha2.Endpoint{
 		Name:       name,
@@ -0,0 +1,73 @@
+//
+//
+// Copyright Red Hat
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validation
+
+import (
+	"fmt"
+
+	"github.com/devfile/api/v2/pkg/apis/workspaces/v1alpha2"
+	attributesAPI "github.com/devfile/api/v2/pkg/attributes"
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+// validateContainerEnv checks the env of the container component, see validateEnv
+func validateContainerEnv(component v1alpha2.Component) (result ValidationResult) {
+	return validateEnv(component.Container.Env, componentTypePath(component), component.Attributes,
+		func(envName string) error {
+			return &ReservedEnvError{envName: envName, componentName: component.Name}
+		},
+		"component", component.Name)
+}
+
+// validateExecCommandEnv checks the env of the exec command, see validateEnv
+func validateExecCommandEnv(command v1alpha2.Command) (result ValidationResult) {
+	return validateEnv(command.Exec.Env, commandTypePath(command), command.Attributes,
+		func(envName string) error {
+			return &ReservedEnvError{envName: envName, commandId: command.Id}
+		},
+		"command", command.Id)
+}
+
+// validateEnv checks if
+//  1. the env names are valid C identifiers
+//  2. the env names are unique within the container component or the exec command
+//  3. the reserved PROJECT_SOURCE and PROJECTS_ROOT env are not customized
+func validateEnv(envs []v1alpha2.EnvVar, parentPath string, attributes attributesAPI.Attributes, newReservedEnvError func(envName string) error,
+	objectType, objectName string) (result ValidationResult) {
+	processedEnvs := make(map[string]bool)
+
+	for _, env := range envs {
+		envPath := fmt.Sprintf("%s/env[name=%s]", parentPath, env.Name)
+
+		if reasons := validation.IsCIdentifier(env.Name); len(reasons) > 0 {
+			envNameErr := &InvalidEnvNameError{envName: env.Name, objectType: objectType, objectName: objectName, reasons: reasons}
+			result.addError(newFinding(RuleEnvName, envNameErr, EnvElement, env.Name, envPath, attributes))
+		}
+
+		if processedEnvs[env.Name] {
+			duplicateEnvErr := &DuplicateEnvError{envName: env.Name, objectType: objectType, objectName: objectName}
+			result.addError(newFinding(RuleEnvDuplicate, duplicateEnvErr, EnvElement, env.Name, envPath, attributes))
+		}
+		processedEnvs[env.Name] = true
+
+		if env.Name == EnvProjectsSrc || env.Name == EnvProjectsRoot {
+			result.addError(newFinding(RuleReservedEnv, newReservedEnvError(env.Name), EnvElement, env.Name, envPath, attributes))
+		}
+	}
+
+	return result
+}
@@ -0,0 +1,144 @@
+//
+//
+// Copyright Red Hat
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validation
+
+import (
+	"testing"
+
+	"github.com/devfile/api/v2/pkg/apis/workspaces/v1alpha2"
+	"github.com/devfile/api/v2/pkg/attributes"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateContainerEnv(t *testing.T) {
+
+	parentOverridesFromMainDevfile := attributes.Attributes{}.PutString(ImportSourceAttribute,
+		"uri: http://127.0.0.1:8080").PutString(ParentOverrideAttribute, "main devfile")
+
+	invalidNameErr := "env variable \"1_INVALID\" in component container1 is invalid: .*C identifier.*"
+	invalidNameWithDashErr := "env variable \"MY-VAR\" in component container1 is invalid"
+	duplicateErr := "env variable JAVA_OPTS is defined multiple times in component container1"
+	reservedErr := "env variable PROJECT_SOURCE is reserved and cannot be customized in component container1"
+	duplicateWithImportAttributesErr := duplicateErr + ", imported from uri: http://127.0.0.1:8080, in parent overrides from main devfile"
+
+	tests := []struct {
+		name       string
+		envs       []v1alpha2.EnvVar
+		attributes attributes.Attributes
+		wantErr    []string
+	}{
+		{
+			name: "Valid env",
+			envs: []v1alpha2.EnvVar{{Name: "JAVA_OPTS", Value: "-Xmx512m"}, {Name: "_debug", Value: "true"}},
+		},
+		{
+			name:    "Invalid env names",
+			envs:    []v1alpha2.EnvVar{{Name: "1_INVALID"}, {Name: "MY-VAR"}},
+			wantErr: []string{invalidNameErr, invalidNameWithDashErr},
+		},
+		{
+			name:    "Duplicate env names",
+			envs:    []v1alpha2.EnvVar{{Name: "JAVA_OPTS"}, {Name: "HOME"}, {Name: "JAVA_OPTS"}},
+			wantErr: []string{duplicateErr},
+		},
+		{
+			name:       "Duplicate env names with import provenance",
+			envs:       []v1alpha2.EnvVar{{Name: "JAVA_OPTS"}, {Name: "JAVA_OPTS"}},
+			attributes: parentOverridesFromMainDevfile,
+			wantErr:    []string{duplicateWithImportAttributesErr},
+		},
+		{
+			name:    "Multiple errors: duplicate reserved env",
+			envs:    []v1alpha2.EnvVar{{Name: EnvProjectsSrc}, {Name: EnvProjectsSrc}},
+			wantErr: []string{reservedErr, "env variable PROJECT_SOURCE is defined multiple times in component container1", reservedErr},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			component := generateDummyContainerComponent("container1", nil, nil, tt.envs, v1alpha2.Annotation{}, false)
+			component.Attributes = tt.attributes
+
+			result := validateContainerEnv(component)
+
+			if assert.Equal(t, len(tt.wantErr), len(result.Errors), "Error list length should match") {
+				for i := 0; i < len(result.Errors); i++ {
+					assert.Regexp(t, tt.wantErr[i], result.Errors[i].Error(), "Error message should match")
+				}
+			}
+		})
+	}
+}
+
+func TestValidateExecCommandEnv(t *testing.T) {
+
+	pluginOverridesFromMainDevfile := attributes.Attributes{}.PutString(ImportSourceAttribute,
+		"id: nodejs").PutString(PluginOverrideAttribute, "main devfile")
+
+	invalidNameErr := "env variable \"MY VAR\" in command run is invalid"
+	duplicateErr := "env variable PORT is defined multiple times in command run"
+	reservedErr := "env variable PROJECTS_ROOT is reserved and cannot be shadowed in exec command run"
+	reservedWithImportAttributesErr := reservedErr + ", imported from id: nodejs, in plugin overrides from main devfile"
+
+	tests := []struct {
+		name       string
+		envs       []v1alpha2.EnvVar
+		attributes attributes.Attributes
+		wantErr    []string
+	}{
+		{
+			name: "Valid env",
+			envs: []v1alpha2.EnvVar{{Name: "PORT", Value: "8080"}},
+		},
+		{
+			name:    "Invalid env name",
+			envs:    []v1alpha2.EnvVar{{Name: "MY VAR"}},
+			wantErr: []string{invalidNameErr},
+		},
+		{
+			name:    "Duplicate env names",
+			envs:    []v1alpha2.EnvVar{{Name: "PORT", Value: "8080"}, {Name: "PORT", Value: "8081"}},
+			wantErr: []string{duplicateErr},
+		},
+		{
+			name:    "Env shadowing a reserved env",
+			envs:    []v1alpha2.EnvVar{{Name: EnvProjectsRoot, Value: "/tmp"}},
+			wantErr: []string{reservedErr},
+		},
+		{
+			name:       "Env shadowing a reserved env with import provenance",
+			envs:       []v1alpha2.EnvVar{{Name: EnvProjectsRoot, Value: "/tmp"}},
+			attributes: pluginOverridesFromMainDevfile,
+			wantErr:    []string{reservedWithImportAttributesErr},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			command := generateDummyExecCommand("run", "container1", nil)
+			command.Exec.Env = tt.envs
+			command.Attributes = tt.attributes
+
+			components := []v1alpha2.Component{generateDummyContainerComponent("container1", nil, nil, nil, v1alpha2.Annotation{}, false)}
+			result := ValidateCommands([]v1alpha2.Command{command}, components)
+
+			if assert.Equal(t, len(tt.wantErr), len(result.Errors), "Error list length should match") {
+				for i := 0; i < len(result.Errors); i++ {
+					assert.Regexp(t, tt.wantErr[i], result.Errors[i].Error(), "Error message should match")
+				}
+			}
+		})
+	}
+}
@@ -18,11 +18,58 @@ package validation
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/devfile/api/v2/pkg/apis/workspaces/v1alpha2"
-	attributesAPI "github.com/devfile/api/v2/pkg/attributes"
 )
 
+// DuplicateRuleError returns an error if a custom validation rule is registered twice
+type DuplicateRuleError struct {
+	ruleID RuleID
+}
+
+func (e *DuplicateRuleError) Error() string {
+	return fmt.Sprintf("a validation rule with id %s is already registered", e.ruleID)
+}
+
+// InvalidRuleFindingError returns an error if a custom validation rule returns a finding without error
+type InvalidRuleFindingError struct {
+	ruleID RuleID
+}
+
+func (e *InvalidRuleFindingError) Error() string {
+	return fmt.Sprintf("the validation rule %s returned a finding without error", e.ruleID)
+}
+
+// InvalidSchemaVersionError returns an error if the devfile schema version is not a valid devfile 2.x.x or later version
+type InvalidSchemaVersionError struct {
+	schemaVersion string
+}
+
+func (e *InvalidSchemaVersionError) Error() string {
+	return fmt.Sprintf("schema version %q is invalid, it should be a semantic version starting from 2.0.0", e.schemaVersion)
+}
+
+// UnsupportedSchemaFeatureError returns an error if the devfile uses a construct introduced after its schema version
+type UnsupportedSchemaFeatureError struct {
+	feature       string
+	since         string
+	schemaVersion string
+}
+
+func (e *UnsupportedSchemaFeatureError) Error() string {
+	return fmt.Sprintf("%s requires schemaVersion %s or later, but the schemaVersion is %s", e.feature, e.since, e.schemaVersion)
+}
+
+// DuplicateKeyError returns an error if two elements of a devfile top-level list share the same key
+type DuplicateKeyError struct {
+	key string
+}
+
+func (e *DuplicateKeyError) Error() string {
+	return fmt.Sprintf("duplicate key: %s", e.key)
+}
+
 // InvalidEventError returns an error if the devfile event type has invalid events
 type InvalidEventError struct {
 	eventType string
@@ -37,6 +84,9 @@ func (e *InvalidEventError) Error() string {
 type InvalidCommandError struct {
 	commandId string
 	reason    string
+
+	// reference is the unknown component name or command id referenced by the command, if any
+	reference string
 }
 
 func (e *InvalidCommandError) Error() string {
@@ -72,16 +122,62 @@ func (e *MissingDefaultCmdWarning) Error() string {
 	return fmt.Sprintf("command group %s warning - there should be exactly one default command, currently there is no default command", e.groupKind)
 }
 
-// ReservedEnvError returns an error if the user attempts to customize a reserved ENV in a container
+// IgnoredHotReloadCapableWarning returns a warning if hotReloadCapable is set on an exec command it is not taken into account for
+type IgnoredHotReloadCapableWarning struct {
+	commandId string
+	reason    string
+}
+
+func (e *IgnoredHotReloadCapableWarning) Error() string {
+	return fmt.Sprintf("hotReloadCapable of exec command %s is ignored, it is only taken into account for the default build, run and debug commands: %s", e.commandId, e.reason)
+}
+
+// DeployGroupExecCommandError returns an error if an exec command belongs to the deploy group
+type DeployGroupExecCommandError struct {
+	commandId string
+}
+
+func (e *DeployGroupExecCommandError) Error() string {
+	return fmt.Sprintf("exec command %s cannot belong to the deploy group, the deploy group is meant for apply commands referencing image, kubernetes or openshift components", e.commandId)
+}
+
+// ReservedEnvError returns an error if the user attempts to customize a reserved ENV in a container or an exec command
 type ReservedEnvError struct {
 	componentName string
+	commandId     string
 	envName       string
 }
 
 func (e *ReservedEnvError) Error() string {
+	if e.commandId != "" {
+		return fmt.Sprintf("env variable %s is reserved and cannot be shadowed in exec command %s", e.envName, e.commandId)
+	}
 	return fmt.Sprintf("env variable %s is reserved and cannot be customized in component %s", e.envName, e.componentName)
 }
 
+// InvalidEnvNameError returns an error if the env name of a container or an exec command is not a valid C identifier
+type InvalidEnvNameError struct {
+	envName    string
+	objectType string
+	objectName string
+	reasons    []string
+}
+
+func (e *InvalidEnvNameError) Error() string {
+	return fmt.Sprintf("env variable %q in %s %s is invalid: %s", e.envName, e.objectType, e.objectName, strings.Join(e.reasons, "; "))
+}
+
+// DuplicateEnvError returns an error if an env variable is defined multiple times in a container or an exec command
+type DuplicateEnvError struct {
+	envName    string
+	objectType string
+	objectName string
+}
+
+func (e *DuplicateEnvError) Error() string {
+	return fmt.Sprintf("env variable %s is defined multiple times in %s %s", e.envName, e.objectType, e.objectName)
+}
+
 // InvalidVolumeError returns an error if the volume is invalid
 type InvalidVolumeError struct {
 	name   string
@@ -94,17 +190,72 @@ func (e *InvalidVolumeError) Error() string {
 
 // MissingVolumeMountError returns an error if the container volume mount does not reference a valid volume component
 type MissingVolumeMountError struct {
-	errMsg string
+	volumeName    string
+	componentName string
 }
 
 func (e *MissingVolumeMountError) Error() string {
-	return fmt.Sprintf("unable to find the following volume mounts in devfile volume components: %s", e.errMsg)
+	return fmt.Sprintf("unable to find the following volume mounts in devfile volume components: volume mount %s belonging to the container component %s", e.volumeName, e.componentName)
+}
+
+// InvalidVolumeMountPathError returns an error if the path of a container volume mount is relative or not normalized
+type InvalidVolumeMountPathError struct {
+	volumeName    string
+	componentName string
+	path          string
+	reason        string
+}
+
+func (e *InvalidVolumeMountPathError) Error() string {
+	return fmt.Sprintf("the path %s of volume mount %s in container component %s is invalid: %s", e.path, e.volumeName, e.componentName, e.reason)
+}
+
+// DuplicateVolumeMountPathError returns an error if multiple volume mounts of a container resolve to the same path
+type DuplicateVolumeMountPathError struct {
+	componentName string
+	path          string
+	volumeNames   []string
+}
+
+func (e *DuplicateVolumeMountPathError) Error() string {
+	return fmt.Sprintf("volume mounts %s in container component %s are mounted to the same path %s", strings.Join(e.volumeNames, " and "), e.componentName, e.path)
+}
+
+// VolumeMountSourceMappingError returns an error if a volume mount is mounted to the path the project sources are mounted to
+type VolumeMountSourceMappingError struct {
+	volumeName    string
+	componentName string
+	path          string
+}
+
+func (e *VolumeMountSourceMappingError) Error() string {
+	return fmt.Sprintf("volume mount %s in container component %s is mounted to the path %s which collides with the sourceMapping of the container", e.volumeName, e.componentName, e.path)
+}
+
+// EphemeralVolumeSizeWarning returns a warning if an ephemeral volume declares a size
+type EphemeralVolumeSizeWarning struct {
+	name string
+}
+
+func (e *EphemeralVolumeSizeWarning) Error() string {
+	return fmt.Sprintf("volume component %s is ephemeral, its size is ignored", e.name)
+}
+
+// UnusedVolumeWarning returns a warning if a volume component is not mounted by any container
+type UnusedVolumeWarning struct {
+	name string
+}
+
+func (e *UnusedVolumeWarning) Error() string {
+	return fmt.Sprintf("volume component %s is not mounted by any container component", e.name)
 }
 
 // InvalidEndpointError returns an error if the component endpoint is invalid
 type InvalidEndpointError struct {
-	name string
-	port int
+	name                     string
+	port                     int
+	componentName            string
+	conflictingComponentName string
 }
 
 func (e *InvalidEndpointError) Error() string {
@@ -113,11 +264,135 @@ func (e *InvalidEndpointError) Error() string {
 		errMsg = fmt.Sprintf("devfile contains multiple endpoint entries with same name: %v", e.name)
 	} else if fmt.Sprint(e.port) != "" {
 		errMsg = fmt.Sprintf("devfile contains multiple containers with same endpoint targetPort: %v", e.port)
+		if e.componentName != "" && e.conflictingComponentName != "" {
+			errMsg = fmt.Sprintf("%s, used by both container components %s and %s in the same pod", errMsg, e.conflictingComponentName, e.componentName)
+		}
 	}
 
 	return errMsg
 }
 
+// InsecureEndpointProtocolError returns an error if a secure endpoint does not use a secure protocol
+type InsecureEndpointProtocolError struct {
+	endpointName  string
+	componentName string
+	protocol      v1alpha2.EndpointProtocol
+}
+
+func (e *InsecureEndpointProtocolError) Error() string {
+	return fmt.Sprintf("endpoint %s of component %s is secure but uses the %s protocol, a secure endpoint requires the https or wss protocol", e.endpointName, e.componentName, e.protocol)
+}
+
+// InvalidEndpointTargetPortError returns an error if the endpoint target port is not a valid port number
+type InvalidEndpointTargetPortError struct {
+	endpointName  string
+	componentName string
+	targetPort    int
+}
+
+func (e *InvalidEndpointTargetPortError) Error() string {
+	return fmt.Sprintf("endpoint %s of component %s has an invalid targetPort %d, the targetPort must be between 1 and 65535", e.endpointName, e.componentName, e.targetPort)
+}
+
+// InvalidEndpointPathError returns an error if the endpoint path is not a valid URL path
+type InvalidEndpointPathError struct {
+	endpointName  string
+	componentName string
+	path          string
+	reason        string
+}
+
+func (e *InvalidEndpointPathError) Error() string {
+	return fmt.Sprintf("endpoint %s of component %s has an invalid path %q: %s", e.endpointName, e.componentName, e.path, e.reason)
+}
+
+// InvalidEndpointAnnotationKeyError returns an error if the endpoint annotation key is not a valid Kubernetes annotation key
+type InvalidEndpointAnnotationKeyError struct {
+	endpointName  string
+	componentName string
+	key           string
+	reasons       []string
+}
+
+func (e *InvalidEndpointAnnotationKeyError) Error() string {
+	return fmt.Sprintf("endpoint %s of component %s has an invalid annotation key %q: %s", e.endpointName, e.componentName, e.key, strings.Join(e.reasons, "; "))
+}
+
+// UnexposedEndpointAnnotationError returns an error if ingress or route annotations are set on an endpoint that is not exposed
+type UnexposedEndpointAnnotationError struct {
+	endpointName  string
+	componentName string
+	keys          []string
+}
+
+func (e *UnexposedEndpointAnnotationError) Error() string {
+	return fmt.Sprintf("endpoint %s of component %s has exposure none, its ingress or route annotations %s are ignored",
+		e.endpointName, e.componentName, strings.Join(e.keys, ", "))
+}
+
+// InvalidManifestError returns an error if the inlined manifest of a component cannot be parsed
+type InvalidManifestError struct {
+	componentName string
+	reason        string
+}
+
+func (e *InvalidManifestError) Error() string {
+	return fmt.Sprintf("unable to parse the inlined manifest of component %s: %s", e.componentName, e.reason)
+}
+
+// MissingManifestFieldError returns an error if a required field is missing in an object of the inlined manifest of a component
+type MissingManifestFieldError struct {
+	componentName string
+	document      int
+	field         string
+}
+
+func (e *MissingManifestFieldError) Error() string {
+	return fmt.Sprintf("the inlined manifest of component %s is missing %s in document %d", e.componentName, e.field, e.document)
+}
+
+// UnexposedEndpointPortError returns an error if the target port of an endpoint is not exposed by the inlined manifest of the component
+type UnexposedEndpointPortError struct {
+	componentName string
+	endpointName  string
+	targetPort    int
+}
+
+func (e *UnexposedEndpointPortError) Error() string {
+	return fmt.Sprintf("endpoint %s targetPort %d is not exposed by a Service or a container port in the inlined manifest of component %s", e.endpointName, e.targetPort, e.componentName)
+}
+
+// InvalidImageReferenceError returns an error if the image of a component is not a valid image reference
+type InvalidImageReferenceError struct {
+	componentName string
+	image         string
+	reason        string
+}
+
+func (e *InvalidImageReferenceError) Error() string {
+	return fmt.Sprintf("the image %q of component %s is invalid: %s", e.image, e.componentName, e.reason)
+}
+
+// MutableImageTagError returns an error if the image of a component is untagged or uses the latest tag
+type MutableImageTagError struct {
+	componentName string
+	image         string
+}
+
+func (e *MutableImageTagError) Error() string {
+	return fmt.Sprintf("the image %q of component %s is untagged or uses the latest tag, the image should be pinned to a version", e.image, e.componentName)
+}
+
+// MissingImageDigestError returns an error if the image of a component is not pinned by digest
+type MissingImageDigestError struct {
+	componentName string
+	image         string
+}
+
+func (e *MissingImageDigestError) Error() string {
+	return fmt.Sprintf("the image %q of component %s must be pinned by digest", e.image, e.componentName)
+}
+
 // InvalidComponentError returns an error if the component is invalid
 type InvalidComponentError struct {
 	componentName string
@@ -157,6 +432,69 @@ func (e *MultipleRemoteError) Error() string {
 	return fmt.Sprintf("%s %s should have one remote only", e.objectType, e.objectName)
 }
 
+// InvalidClonePathError returns an error if the project clonePath is absolute, escapes the root of the projects or is not normalized
+type InvalidClonePathError struct {
+	projectName string
+	clonePath   string
+	reason      string
+}
+
+func (e *InvalidClonePathError) Error() string {
+	return fmt.Sprintf("the clonePath %s of project %s is invalid: %s", e.clonePath, e.projectName, e.reason)
+}
+
+// DuplicateClonePathError returns an error if multiple projects clone into the same directory
+type DuplicateClonePathError struct {
+	cloneDir string
+	projects []string
+}
+
+func (e *DuplicateClonePathError) Error() string {
+	return fmt.Sprintf("%s clone into the same directory %s", strings.Join(e.projects, " and "), e.cloneDir)
+}
+
+// ProjectNameConflictError returns an error if a project and a dependent project have the same name
+type ProjectNameConflictError struct {
+	name string
+}
+
+func (e *ProjectNameConflictError) Error() string {
+	return fmt.Sprintf("project %s is defined both in projects and dependentProjects", e.name)
+}
+
+// InvalidZipLocationError returns an error if the location of a zip project source is not a valid URI with an allowed scheme
+type InvalidZipLocationError struct {
+	objectType string
+	objectName string
+	location   string
+	reason     string
+}
+
+func (e *InvalidZipLocationError) Error() string {
+	return fmt.Sprintf("the zip location %s of %s %s is invalid: %s", e.location, e.objectType, e.objectName, e.reason)
+}
+
+// UnknownCustomClassError returns an error if a custom project source, command or component class is not a known custom class
+type UnknownCustomClassError struct {
+	kind  CustomClassKind
+	class string
+}
+
+func (e *UnknownCustomClassError) Error() string {
+	return fmt.Sprintf("%s %q is not a known custom class", e.kind, e.class)
+}
+
+// InvalidEmbeddedResourceError returns an error if the embedded resource of a custom project source, command or component does not parse
+type InvalidEmbeddedResourceError struct {
+	kind   CustomClassKind
+	class  string
+	reason string
+}
+
+func (e *InvalidEmbeddedResourceError) Error() string {
+	return fmt.Sprintf("the embedded resource of %s %q is invalid: %s", e.kind, e.class, e.reason)
+}
+
 // MissingProjectCheckoutFromRemoteError returns an error if there are multiple git remotes but the checkoutFrom remote has not been specified
 type MissingProjectCheckoutFromRemoteError struct {
 	projectName string
@@ -207,6 +545,73 @@ func (e *InvalidResourceRequestError) Error() string {
 	return fmt.Sprintf("invalid resource request for component %s: %s", e.cmpName, e.errMsg)
 }
 
+// InvalidGlobalMemoryLimitError returns an error if the devfile global memory limit is not a valid quantity
+type InvalidGlobalMemoryLimitError struct {
+	globalMemoryLimit string
+	errMsg            string
+}
+
+func (e *InvalidGlobalMemoryLimitError) Error() string {
+	return fmt.Sprintf("error parsing globalMemoryLimit %s: %s", e.globalMemoryLimit, e.errMsg)
+}
+
+// MissingMemoryLimitWarning returns a warning if a container component sharing the main pod has no memory limit,
+// the resource budget of the main pod cannot be checked against the global memory limit
+type MissingMemoryLimitWarning struct {
+	componentName string
+}
+
+func (e *MissingMemoryLimitWarning) Error() string {
+	return fmt.Sprintf("container component %s has no memoryLimit, it is not accounted for in the globalMemoryLimit", e.componentName)
+}
+
+// ResourceBudgetExceededError returns an error if the sum of the memory limits of the container components
+// sharing the main pod exceeds the global memory limit
+type ResourceBudgetExceededError struct {
+	globalMemoryLimit string
+	totalMemoryLimit  string
+	breakdown         string
+}
+
+func (e *ResourceBudgetExceededError) Error() string {
+	return fmt.Sprintf("the memoryLimit of the container components sharing the main pod, %s (%s), exceeds the globalMemoryLimit %s",
+		e.totalMemoryLimit, e.breakdown, e.globalMemoryLimit)
+}
+
+// PodResourceBudgetInfo reports the resource budget of a pod, summed up from its container components
+type PodResourceBudgetInfo struct {
+	pod        string
+	containers []string
+	totals     string
+}
+
+func (e *PodResourceBudgetInfo) Error() string {
+	pod := "main pod"
+	if e.pod != mainPod {
+		pod = fmt.Sprintf("dedicated pod %s", e.pod)
+	}
+	return fmt.Sprintf("%s: %s, for the container components %s", pod, e.totals, strings.Join(e.containers, ", "))
+}
+
+// VariableSourceInfo reports the source supplying the value of a global variable
+type VariableSourceInfo struct {
+	key    string
+	source string
+}
+
+func (e *VariableSourceInfo) Error() string {
+	return fmt.Sprintf("variable %s is supplied by the %s source", e.key, e.source)
+}
+
+// UndefinedVariableInfo reports a global variable referenced in the devfile without being defined by any source
+type UndefinedVariableInfo struct {
+	key string
+}
+
+func (e *UndefinedVariableInfo) Error() string {
+	return fmt.Sprintf("variable %s is referenced but not defined", e.key)
+}
+
 type AnnotationType string
 
 const (
@@ -224,34 +629,110 @@ func (e *AnnotationConflictError) Error() string {
 	return fmt.Sprintf("%v annotation: %v has been declared multiple times and with different values", e.annotationType, e.annotationName)
 }
 
-// resolveErrorMessageWithImportAttributes returns an updated error message
-// with detailed information on the imported and overriden resource.
-// example:
-// "the component <compName> is invalid - <reason>, imported from Uri: http://example.com/devfile.yaml, in parent overrides from main devfile"
-func resolveErrorMessageWithImportAttributes(validationErr error, attributes attributesAPI.Attributes) error {
-	var findKeyErr error
-	importReference := attributes.Get(ImportSourceAttribute, &findKeyErr)
-
-	// overridden element must contain import resource information
-	// an overridden element can be either parentOverride or pluginOverride
-	// example:
-	// if an element is imported from another devfile, but contains no overrides - ImportSourceAttribute
-	// if an element is from parentOverride - ImportSourceAttribute + ParentOverrideAttribute
-	// if an element is from pluginOverride - ImportSourceAttribute + PluginOverrideAttribute
-	if findKeyErr == nil {
-		validationErr = fmt.Errorf("%s, imported from %s", validationErr.Error(), importReference)
-		parentOverrideReference := attributes.Get(ParentOverrideAttribute, &findKeyErr)
-		if findKeyErr == nil {
-			validationErr = fmt.Errorf("%s, in parent overrides from %s", validationErr.Error(), parentOverrideReference)
-		} else {
-			// reset findKeyErr to nil
-			findKeyErr = nil
-			pluginOverrideReference := attributes.Get(PluginOverrideAttribute, &findKeyErr)
-			if findKeyErr == nil {
-				validationErr = fmt.Errorf("%s, in plugin overrides from %s", validationErr.Error(), pluginOverrideReference)
-			}
-		}
-	}
+// InvalidDockerfileArgError returns an error if a dockerfile arg of an image component is not in the KEY=VALUE form
+type InvalidDockerfileArgError struct {
+	componentName string
+	arg           string
+}
+
+func (e *InvalidDockerfileArgError) Error() string {
+	return fmt.Sprintf("the dockerfile arg %q of image component %s must be in the KEY=VALUE form", e.arg, e.componentName)
+}
+
+// InvalidBuildContextError returns an error if the dockerfile build context of an image component is an absolute path
+// or starts with an env reference other than ${PROJECT_SOURCE}
+type InvalidBuildContextError struct {
+	componentName string
+	buildContext  string
+}
+
+func (e *InvalidBuildContextError) Error() string {
+	return fmt.Sprintf("the buildContext %s of image component %s must be a relative path or start with ${%s}", e.buildContext, e.componentName, EnvProjectsSrc)
+}
+
+// InvalidDockerfileRegistryIdError returns an error if the devfile registry id of an image component is invalid
+type InvalidDockerfileRegistryIdError struct {
+	componentName string
+	id            string
+}
+
+func (e *InvalidDockerfileRegistryIdError) Error() string {
+	return fmt.Sprintf("the devfile registry id %q of image component %s must be a lowercase alphanumeric id, with dashes, of at most 63 characters",
+		e.id, e.componentName)
+}
+
+// MissingDockerfileRegistryUrlWarning returns a warning if the devfile registry source of an image component has no registry url,
+// the dockerfile may not be resolved consistently in different environments
+type MissingDockerfileRegistryUrlWarning struct {
+	componentName string
+	id            string
+}
+
+func (e *MissingDockerfileRegistryUrlWarning) Error() string {
+	return fmt.Sprintf("the devfile registry id %s of image component %s should come with a registryUrl, so the dockerfile is resolved consistently",
+		e.id, e.componentName)
+}
+
+// InvalidDockerfileLocationError returns an error if the dockerfile location of an image component git source is absolute
+// or escapes the repository
+type InvalidDockerfileLocationError struct {
+	componentName string
+	fileLocation  string
+	reason        string
+}
+
+func (e *InvalidDockerfileLocationError) Error() string {
+	return fmt.Sprintf("the dockerfile fileLocation %s of image component %s is invalid: %s", e.fileLocation, e.componentName, e.reason)
+}
+
+// MultipleImportReferenceSourcesError returns an error if an import reference defines more than one of uri, id or kubernetes
+type MultipleImportReferenceSourcesError struct {
+	element string
+	sources []string
+}
+
+func (e *MultipleImportReferenceSourcesError) Error() string {
+	return fmt.Sprintf("%s must reference only one of uri, id or kubernetes, found %s", e.element, strings.Join(e.sources, ", "))
+}
+
+// ImportReferenceTypeMismatchError returns an error if the importReferenceType does not match the source of the import reference
+type ImportReferenceTypeMismatchError struct {
+	element       string
+	referenceType v1alpha2.ImportReferenceType
+}
+
+func (e *ImportReferenceTypeMismatchError) Error() string {
+	return fmt.Sprintf("%s has importReferenceType %s, but does not define %s", e.element, e.referenceType, strings.ToLower(string(e.referenceType)))
+}
+
+// IdOnlyImportFieldError returns an error if the registryUrl or version of an import reference is set without id
+type IdOnlyImportFieldError struct {
+	element string
+	field   string
+}
+
+func (e *IdOnlyImportFieldError) Error() string {
+	return fmt.Sprintf("%s defines %s, which can only be used with an id import reference", e.element, e.field)
+}
+
+// InvalidImportVersionError returns an error if the version of an import reference is neither latest nor a semantic version
+type InvalidImportVersionError struct {
+	element string
+	version string
+}
+
+func (e *InvalidImportVersionError) Error() string {
+	return fmt.Sprintf("version %s of %s is invalid, it must be latest or a semantic version, e.g. 1.0.0", e.version, e.element)
+}
+
+// InvalidKubernetesImportReferenceError returns an error if the name or namespace of a kubernetes import reference is invalid
+type InvalidKubernetesImportReferenceError struct {
+	element string
+	field   string
+	value   string
+	reason  string
+}
 
-	return validationErr
+func (e *InvalidKubernetesImportReferenceError) Error() string {
+	return fmt.Sprintf("kubernetes %s %q of %s is invalid: %s", e.field, e.value, e.element, e.reason)
 }
@@ -18,7 +18,6 @@ package validation
 
 import (
 	"fmt"
-	"github.com/hashicorp/go-multierror"
 	"strings"
 
 	"github.com/devfile/api/v2/pkg/apis/workspaces/v1alpha2"
@@ -32,39 +31,66 @@ const (
 )
 
 // ValidateEvents validates all the devfile events
-func ValidateEvents(events v1alpha2.Events, commands []v1alpha2.Command) (err error) {
+//
+// The custom rules of the events scope registered in DefaultRuleRegistry are run along with the built-in checks
+func ValidateEvents(events v1alpha2.Events, commands []v1alpha2.Command) (result ValidationResult) {
+	result = validateEvents(events, commands)
+	result.Merge(DefaultRuleRegistry.run(EventsScope, RuleContext{Events: &events, Commands: commands}))
+	return DefaultRuleRegistry.filter(result)
+}
+
+// validateEvents runs the built-in checks of the events
+func validateEvents(events v1alpha2.Events, commands []v1alpha2.Command) (result ValidationResult) {
 
 	commandMap := getCommandsMap(commands)
+	var commandIds []string
+	for _, command := range commands {
+		commandIds = append(commandIds, command.Id)
+	}
+
+	// addEventError reports the invalid events of the event type, along with the closest command ids
+	// of the events not mapping to a devfile command
+	addEventError := func(eventType string, eventNames []string, err error) {
+		finding := newFinding(RuleEventCommand, err, EventElement, eventType, fmt.Sprintf("/events/%s", eventType), nil)
+		for eventIndex, eventName := range eventNames {
+			if _, ok := commandMap[strings.ToLower(eventName)]; !ok {
+				addKeySuggestion(finding, eventName, fmt.Sprintf("/events/%s/%d", eventType, eventIndex), commandIds)
+			}
+		}
+		result.addError(finding)
+	}
 
 	switch {
 	case len(events.PreStart) > 0:
 		if preStartErr := isEventValid(events.PreStart, preStart, commandMap); preStartErr != nil {
-			err = multierror.Append(err, preStartErr)
+			addEventError(preStart, events.PreStart, preStartErr)
 		}
 		fallthrough
 	case len(events.PostStart) > 0:
 		if postStartErr := isEventValid(events.PostStart, postStart, commandMap); postStartErr != nil {
-			err = multierror.Append(err, postStartErr)
+			addEventError(postStart, events.PostStart, postStartErr)
 		}
 		fallthrough
 	case len(events.PreStop) > 0:
 		if preStopErr := isEventValid(events.PreStop, preStop, commandMap); preStopErr != nil {
-			err = multierror.Append(err, preStopErr)
+			addEventError(preStop, events.PreStop, preStopErr)
 		}
 		fallthrough
 	case len(events.PostStop) > 0:
 		if postStopErr := isEventValid(events.PostStop, postStop, commandMap); postStopErr != nil {
-			err = multierror.Append(err, postStopErr)
+			addEventError(postStop, events.PostStop, postStopErr)
 		}
 	}
 
-	return err
+	return result
 }
 
 // isEventValid checks if events belonging to a specific event type are valid ie;
 // 1. event should map to a valid devfile command
 // 2. preStart and postStop events should either map to an apply command or a composite command with apply commands
 // 3. postStart and preStop events should either map to an exec command or a composite command with exec commands
+//
+// The composite commands are checked through the nested composite commands, see validateCompositeEvents
 func isEventValid(eventNames []string, eventType string, commandMap map[string]v1alpha2.Command) error {
 	var invalidCommand, invalidApplyEvents, invalidExecEvents []string
 
@@ -81,14 +107,14 @@ func isEventValid(eventNames []string, eventType string, commandMap map[string]v
 			if command.Apply == nil && command.Composite == nil {
 				invalidApplyEvents = append(invalidApplyEvents, eventName)
 			} else if command.Composite != nil {
-				invalidApplyEvents = append(invalidApplyEvents, validateCompositeEvents(*command.Composite, eventName, eventType, commandMap)...)
+				invalidApplyEvents = append(invalidApplyEvents, validateCompositeEvents(*command.Composite, []string{eventName}, eventType, commandMap)...)
 			}
 		case postStart, preStop:
 			// check if the event is either an exec command or a composite of exec commands
 			if command.Exec == nil && command.Composite == nil {
 				invalidExecEvents = append(invalidExecEvents, eventName)
 			} else if command.Composite != nil {
-				invalidExecEvents = append(invalidExecEvents, validateCompositeEvents(*command.Composite, eventName, eventType, commandMap)...)
+				invalidExecEvents = append(invalidExecEvents, validateCompositeEvents(*command.Composite, []string{eventName}, eventType, commandMap)...)
 			}
 		}
 	}
@@ -116,26 +142,41 @@ func isEventValid(eventNames []string, eventType string, commandMap map[string]v
 	return err
 }
 
-// validateCompositeEvents checks if a composite subcommands are
+// validateCompositeEvents walks the composite command tree of an event and checks if the leaf subcommands are
 // 1. apply commands for preStart and postStop
 // 2. exec commands for postStart and preStop
-func validateCompositeEvents(composite v1alpha2.CompositeCommand, eventName, eventType string, commandMap map[string]v1alpha2.Command) []string {
+//
+// Each invalid subcommand is reported with the full chain from the event to the offending command,
+// e.g. "buildAll -> buildImages -> runTests". The subcommands are resolved case-insensitively as in validateCompositeCommand,
+// the missing subcommands and the composite cycles are reported by the command validation.
+func validateCompositeEvents(composite v1alpha2.CompositeCommand, chain []string, eventType string, commandMap map[string]v1alpha2.Command) []string {
 	var invalidEvents []string
 
-	switch eventType {
-	case preStart, postStop:
-		for _, subCommand := range composite.Commands {
-			if command, ok := commandMap[subCommand]; ok && command.Apply == nil {
-				invalidEvents = append(invalidEvents, eventName)
-			}
+	for _, subCommand := range composite.Commands {
+		command, ok := commandMap[strings.ToLower(subCommand)]
+		if !ok || isInChain(subCommand, chain) {
+			continue
 		}
-	case postStart, preStop:
-		for _, subCommand := range composite.Commands {
-			if command, ok := commandMap[subCommand]; ok && command.Exec == nil {
-				invalidEvents = append(invalidEvents, eventName)
-			}
+		subChain := append(append([]string{}, chain...), subCommand)
+
+		switch {
+		case command.Composite != nil:
+			invalidEvents = append(invalidEvents, validateCompositeEvents(*command.Composite, subChain, eventType, commandMap)...)
+		case (eventType == preStart || eventType == postStop) && command.Apply == nil,
+			(eventType == postStart || eventType == preStop) && command.Exec == nil:
+			invalidEvents = append(invalidEvents, strings.Join(subChain, " -> "))
 		}
 	}
 
 	return invalidEvents
 }
+
+// isInChain returns true if the command id is already in the composite command chain
+func isInChain(commandId string, chain []string) bool {
+	for _, chainCommandId := range chain {
+		if strings.EqualFold(commandId, chainCommandId) {
+			return true
+		}
+	}
+	return false
+}
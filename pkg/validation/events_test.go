@@ -132,7 +132,7 @@ func TestValidateEvents(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			err := ValidateEvents(tt.events, commands)
+			err := ValidateEvents(tt.events, commands).Err()
 
 			if merr, ok := err.(*multierror.Error); ok && tt.wantErr != nil {
 				assert.Equal(t, len(tt.wantErr), len(merr.Errors), "Error list length should match")
@@ -146,6 +146,70 @@ func TestValidateEvents(t *testing.T) {
 	}
 }
 
+func TestValidateEventsCaseInsensitiveCommandIds(t *testing.T) {
+
+	components := []v1alpha2.Component{
+		generateDummyContainerComponent("container1", nil, nil, nil, v1alpha2.Annotation{}, false),
+		generateDummyContainerComponent("container2", nil, nil, nil, v1alpha2.Annotation{}, false),
+	}
+
+	commands := []v1alpha2.Command{
+		generateDummyApplyCommand("apply1", "container1", nil, attributes.Attributes{}),
+		generateDummyExecCommand("exec1", "container2", nil),
+		generateDummyCompositeCommand("BuildImages", []string{"APPLY1"}, nil),
+		generateDummyCompositeCommand("buildAll", []string{"BuildImages", "Apply1"}, nil),
+		generateDummyCompositeCommand("RunTests", []string{"EXEC1"}, nil),
+		generateDummyCompositeCommand("startAll", []string{"runtests", "Exec1"}, nil),
+		generateDummyCompositeCommand("MixedAll", []string{"BUILDALL", "RunTests"}, nil),
+	}
+
+	// the composite commands resolve the mixed-case subcommand ids as the events do
+	assert.NoError(t, ValidateCommands(commands, components).Err(), "Commands should be valid")
+
+	tests := []struct {
+		name    string
+		events  v1alpha2.Events
+		wantErr []string
+	}{
+		{
+			name: "Valid preStart events - Nested Composite Apply Commands with mixed-case ids",
+			events: v1alpha2.Events{
+				DevWorkspaceEvents: v1alpha2.DevWorkspaceEvents{
+					PreStart: []string{"BUILDALL"},
+				},
+			},
+		},
+		{
+			name: "Valid postStart events - Nested Composite Exec Commands with mixed-case ids",
+			events: v1alpha2.Events{
+				DevWorkspaceEvents: v1alpha2.DevWorkspaceEvents{
+					PostStart: []string{"StartAll"},
+				},
+			},
+		},
+		{
+			name: "Invalid preStart events - Exec Command nested in Composite Commands with mixed-case ids",
+			events: v1alpha2.Events{
+				DevWorkspaceEvents: v1alpha2.DevWorkspaceEvents{
+					PreStart: []string{"mixedall"},
+				},
+			},
+			wantErr: []string{"preStart type events are invalid: \nmixedall -> RunTests -> EXEC1 should either map to an apply command or a composite command with apply commands"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := ValidateEvents(tt.events, commands)
+
+			if assert.Equal(t, len(tt.wantErr), len(result.Errors), "Error list length should match") {
+				for i := 0; i < len(result.Errors); i++ {
+					assert.Regexp(t, tt.wantErr[i], result.Errors[i].Error(), "Error message should match")
+				}
+			}
+		})
+	}
+}
+
 func TestIsEventValid(t *testing.T) {
 
 	containers := []string{"container1", "container2"}
@@ -158,11 +222,18 @@ func TestIsEventValid(t *testing.T) {
 		generateDummyCompositeCommand("compositeOnlyApply", []string{"apply1", "apply2"}, nil),
 		generateDummyCompositeCommand("compositeOnlyExec", []string{"exec1", "exec2"}, nil),
 		generateDummyCompositeCommand("compositeExecApply", []string{"exec1", "apply1"}, nil),
+		generateDummyCompositeCommand("compositeNestedApply", []string{"EXEC2", "compositeOnlyApply"}, nil),
+		generateDummyCompositeCommand("compositeNestedExec", []string{"Exec1", "CompositeOnlyExec"}, nil),
+		generateDummyCompositeCommand("compositeTwoLevels", []string{"compositeNestedApply"}, nil),
+		generateDummyCompositeCommand("compositeCycle", []string{"exec1", "compositeCycle2"}, nil),
+		generateDummyCompositeCommand("compositeCycle2", []string{"compositeCycle"}, nil),
 	}
 
 	missingCmdErr := "does not map to a valid devfile command"
 	applyCmdErr := "should either map to an apply command or a composite command with apply commands"
 	execCmdErr := "should either map to an exec command or a composite command with exec commands"
+	nestedApplyChainErr := "compositeTwoLevels -> compositeNestedApply -> compositeOnlyApply -> apply1, compositeTwoLevels -> compositeNestedApply -> compositeOnlyApply -> apply2 " + execCmdErr
+	nestedExecChainErr := "compositeNestedApply -> EXEC2 " + applyCmdErr
 
 	tests := []struct {
 		name       string
@@ -228,6 +299,36 @@ func TestIsEventValid(t *testing.T) {
 			},
 			wantErr: &execCmdErr,
 		},
+		{
+			name:      "Valid postStart events - Nested Composite Exec Commands with case-insensitive ids",
+			eventType: postStart,
+			eventNames: []string{
+				"compositeNestedExec",
+			},
+		},
+		{
+			name:      "Valid postStart events - Composite Command cycle",
+			eventType: postStart,
+			eventNames: []string{
+				"compositeCycle",
+			},
+		},
+		{
+			name:      "Invalid postStart events - Apply Commands nested in two levels of Composite Commands",
+			eventType: postStart,
+			eventNames: []string{
+				"compositeTwoLevels",
+			},
+			wantErr: &nestedApplyChainErr,
+		},
+		{
+			name:      "Invalid preStart events - Exec Command in a Composite Command with a nested Composite Command",
+			eventType: preStart,
+			eventNames: []string{
+				"compositeNestedApply",
+			},
+			wantErr: &nestedExecChainErr,
+		},
 		{
 			name:      "Invalid events - Missing event",
 			eventType: preStop,
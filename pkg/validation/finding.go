@@ -0,0 +1,301 @@
+//
+//
+// Copyright Red Hat
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validation
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/devfile/api/v2/pkg/apis/workspaces/v1alpha2"
+	attributesAPI "github.com/devfile/api/v2/pkg/attributes"
+)
+
+// RuleID is a stable identifier of a validation rule
+type RuleID string
+
+const (
+	RuleDuplicateKey              RuleID = "duplicate-key"
+	RuleReservedEnv               RuleID = "reserved-env"
+	RuleEnvName                   RuleID = "env-name"
+	RuleEnvDuplicate              RuleID = "env-duplicate"
+	RuleResourceQuantity          RuleID = "resource-quantity"
+	RuleResourceRequest           RuleID = "resource-request"
+	RuleResourceBudget            RuleID = "resource-budget"
+	RuleMissingMemoryLimit        RuleID = "missing-memory-limit"
+	RuleResourceBreakdown         RuleID = "resource-breakdown"
+	RuleAnnotationConflict        RuleID = "annotation-conflict"
+	RuleEndpoint                  RuleID = "endpoint"
+	RuleEndpointDuplicateName     RuleID = "endpoint-duplicate-name"
+	RuleEndpointDuplicatePort     RuleID = "endpoint-duplicate-port"
+	RuleEndpointSecureProtocol    RuleID = "endpoint-secure-protocol"
+	RuleEndpointTargetPort        RuleID = "endpoint-target-port"
+	RuleEndpointPath              RuleID = "endpoint-path"
+	RuleEndpointAnnotationKey     RuleID = "endpoint-annotation-key"
+	RuleEndpointExposure          RuleID = "endpoint-exposure"
+	RuleManifestParse             RuleID = "manifest-parse"
+	RuleManifestField             RuleID = "manifest-field"
+	RuleManifestEndpointPort      RuleID = "manifest-endpoint-port"
+	RuleImageReference            RuleID = "image-reference"
+	RuleImageTag                  RuleID = "image-tag"
+	RuleImageDigest               RuleID = "image-digest"
+	RuleDockerfileArg             RuleID = "dockerfile-arg"
+	RuleDockerfileBuildContext    RuleID = "dockerfile-build-context"
+	RuleDockerfileRegistryId      RuleID = "dockerfile-registry-id"
+	RuleDockerfileRegistryUrl     RuleID = "dockerfile-registry-url"
+	RuleDockerfileLocation        RuleID = "dockerfile-location"
+	RuleVolumeSize                RuleID = "volume-size"
+	RuleMissingVolumeMount        RuleID = "missing-volume-mount"
+	RuleVolumeMountPath           RuleID = "volume-mount-path"
+	RuleVolumeMountDuplicatePath  RuleID = "volume-mount-duplicate-path"
+	RuleVolumeMountSourceMapping  RuleID = "volume-mount-source-mapping"
+	RuleVolumeEphemeralSize       RuleID = "volume-ephemeral-size"
+	RuleUnusedVolume              RuleID = "unused-volume"
+	RuleImportReference           RuleID = "import-reference"
+	RuleInvalidURI                RuleID = "invalid-uri"
+	RuleGitRemote                 RuleID = "git-remote"
+	RuleCommandType               RuleID = "command-type"
+	RuleCommandComponent          RuleID = "command-component"
+	RuleCompositeCommand          RuleID = "composite-command"
+	RuleGroupMissingDefault       RuleID = "group-missing-default"
+	RuleGroupMultipleDefault      RuleID = "group-multiple-default"
+	RuleHotReloadCapable          RuleID = "hot-reload-capable"
+	RuleDeployGroup               RuleID = "deploy-group"
+	RuleEventCommand              RuleID = "event-command"
+	RuleProjectRemote             RuleID = "project-remote"
+	RuleProjectCheckoutRemote     RuleID = "project-checkout-remote"
+	RuleProjectMissingCheckout    RuleID = "project-missing-checkout"
+	RuleProjectClonePath          RuleID = "project-clone-path"
+	RuleProjectDuplicateClonePath RuleID = "project-duplicate-clone-path"
+	RuleProjectNameConflict       RuleID = "project-name-conflict"
+	RuleZipLocation               RuleID = "zip-location"
+	RuleCustomClass               RuleID = "custom-class"
+	RuleSchemaFeature             RuleID = "schema-feature"
+	RuleSchemaVersion             RuleID = "schema-version"
+	RuleVariableReference         RuleID = "variable-reference"
+	RuleVariableCycle             RuleID = "variable-cycle"
+	RuleVariableKeyCollision      RuleID = "variable-key-collision"
+	RuleVariableSource            RuleID = "variable-source"
+	RuleUndefinedVariable         RuleID = "undefined-variable"
+)
+
+// ElementKind is the kind of devfile element a finding refers to
+type ElementKind string
+
+const (
+	ComponentElement        ElementKind = "component"
+	CommandElement          ElementKind = "command"
+	CommandGroupElement     ElementKind = "commandGroup"
+	EndpointElement         ElementKind = "endpoint"
+	EnvElement              ElementKind = "env"
+	VolumeMountElement      ElementKind = "volumeMount"
+	EventElement            ElementKind = "event"
+	ProjectElement          ElementKind = "project"
+	DependentProjectElement ElementKind = "dependentProject"
+	StarterProjectElement   ElementKind = "starterProject"
+	AnnotationElement       ElementKind = "annotation"
+	ParentElement           ElementKind = "parent"
+	DevfileElement          ElementKind = "devfile"
+	SchemaVersionElement    ElementKind = "schemaVersion"
+	MetadataElement         ElementKind = "metadata"
+	PodElement              ElementKind = "pod"
+	VariableElement         ElementKind = "variable"
+)
+
+// ImportProvenance describes where an imported or overridden devfile element comes from
+type ImportProvenance struct {
+	// ImportedFrom is the resource information of the devfile the element is imported from
+	ImportedFrom string `json:"importedFrom"`
+
+	// ParentOverrideFrom is the resource information of the devfile overriding the element in its parent
+	ParentOverrideFrom string `json:"parentOverrideFrom,omitempty"`
+
+	// PluginOverrideFrom is the resource information of the devfile overriding the element in a plugin
+	PluginOverrideFrom string `json:"pluginOverrideFrom,omitempty"`
+}
+
+// String returns the provenance in the form appended to the human readable validation messages
+// example:
+// ", imported from Uri: http://example.com/devfile.yaml, in parent overrides from main devfile"
+func (p *ImportProvenance) String() string {
+	if p == nil {
+		return ""
+	}
+	msg := fmt.Sprintf(", imported from %s", p.ImportedFrom)
+	if p.ParentOverrideFrom != "" {
+		msg = fmt.Sprintf("%s, in parent overrides from %s", msg, p.ParentOverrideFrom)
+	} else if p.PluginOverrideFrom != "" {
+		msg = fmt.Sprintf("%s, in plugin overrides from %s", msg, p.PluginOverrideFrom)
+	}
+	return msg
+}
+
+// Finding is a structured validation finding. It wraps the validation error with the stable id of the rule
+// that reported it, the path, kind and key of the offending element and the element import provenance
+type Finding struct {
+	// RuleID is the stable identifier of the rule that reported the finding
+	RuleID RuleID `json:"ruleId"`
+
+	// Path is a JSON-pointer-style path to the offending element,
+	// e.g. /components[name=runtime]/container/endpoints[name=http]
+	Path string `json:"path"`
+
+	// Kind is the kind of the offending element
+	Kind ElementKind `json:"kind"`
+
+	// Key is the name or id of the offending element
+	Key string `json:"key,omitempty"`
+
+	// Severity is the severity of the finding
+	Severity Severity `json:"severity"`
+
+	// Provenance is the import and override information of the element, nil if the element
+	// is defined in the main devfile
+	Provenance *ImportProvenance `json:"provenance,omitempty"`
+
+	// Suggestion is a human readable fix of the finding, empty if there is no suggested fix
+	Suggestion string `json:"suggestion,omitempty"`
+
+	// Fix is a JSON patch of the devfile fixing the finding, empty if there is no unambiguous fix
+	Fix []PatchOperation `json:"fix,omitempty"`
+
+	// Err is the underlying validation error
+	Err error `json:"-"`
+}
+
+// Error returns the human readable validation message, including the import provenance of the element
+func (f *Finding) Error() string {
+	if f.Err == nil {
+		return (&InvalidRuleFindingError{ruleID: f.RuleID}).Error() + f.Provenance.String()
+	}
+	return f.Err.Error() + f.Provenance.String()
+}
+
+// Unwrap returns the underlying validation error
+func (f *Finding) Unwrap() error {
+	return f.Err
+}
+
+// MarshalJSON serializes the finding along with its human readable message
+func (f *Finding) MarshalJSON() ([]byte, error) {
+	type finding Finding
+	return json.Marshal(&struct {
+		*finding
+		Message string `json:"message"`
+	}{
+		finding: (*finding)(f),
+		Message: f.Error(),
+	})
+}
+
+// newFinding returns a finding for the validation error of the element at the given path,
+// the import provenance is resolved from the attributes of the top-level element
+func newFinding(ruleID RuleID, err error, kind ElementKind, key, path string, attributes attributesAPI.Attributes) *Finding {
+	return &Finding{
+		RuleID:     ruleID,
+		Path:       path,
+		Kind:       kind,
+		Key:        key,
+		Severity:   ErrorSeverity,
+		Provenance: NewImportProvenance(attributes),
+		Err:        err,
+	}
+}
+
+// NewImportProvenance returns the import provenance recorded in the element attributes,
+// or nil if the element is not imported. It can be used by custom rules to fill the finding provenance.
+//
+// an overridden element must contain import resource information
+// an overridden element can be either parentOverride or pluginOverride
+// example:
+// if an element is imported from another devfile, but contains no overrides - ImportSourceAttribute
+// if an element is from parentOverride - ImportSourceAttribute + ParentOverrideAttribute
+// if an element is from pluginOverride - ImportSourceAttribute + PluginOverrideAttribute
+func NewImportProvenance(attributes attributesAPI.Attributes) *ImportProvenance {
+	var findKeyErr error
+	importReference := attributes.Get(ImportSourceAttribute, &findKeyErr)
+	if findKeyErr != nil {
+		return nil
+	}
+
+	provenance := &ImportProvenance{ImportedFrom: fmt.Sprintf("%s", importReference)}
+	parentOverrideReference := attributes.Get(ParentOverrideAttribute, &findKeyErr)
+	if findKeyErr == nil {
+		provenance.ParentOverrideFrom = fmt.Sprintf("%s", parentOverrideReference)
+		return provenance
+	}
+
+	// reset findKeyErr to nil
+	findKeyErr = nil
+	pluginOverrideReference := attributes.Get(PluginOverrideAttribute, &findKeyErr)
+	if findKeyErr == nil {
+		provenance.PluginOverrideFrom = fmt.Sprintf("%s", pluginOverrideReference)
+	}
+
+	return provenance
+}
+
+// componentPath returns the path of the component, e.g. /components[name=runtime]
+func componentPath(component v1alpha2.Component) string {
+	return fmt.Sprintf("/components[name=%s]", component.Name)
+}
+
+// componentTypePath returns the path of the component type specific content, e.g. /components[name=runtime]/container
+func componentTypePath(component v1alpha2.Component) string {
+	var componentType string
+	switch {
+	case component.Container != nil:
+		componentType = "container"
+	case component.Kubernetes != nil:
+		componentType = "kubernetes"
+	case component.Openshift != nil:
+		componentType = "openshift"
+	case component.Volume != nil:
+		componentType = "volume"
+	case component.Image != nil:
+		componentType = "image"
+	case component.Plugin != nil:
+		componentType = "plugin"
+	case component.Custom != nil:
+		componentType = "custom"
+	default:
+		return componentPath(component)
+	}
+	return fmt.Sprintf("%s/%s", componentPath(component), componentType)
+}
+
+// commandPath returns the path of the command, e.g. /commands[id=build]
+func commandPath(command v1alpha2.Command) string {
+	return fmt.Sprintf("/commands[id=%s]", command.Id)
+}
+
+// commandTypePath returns the path of the command type specific content, e.g. /commands[id=build]/exec
+func commandTypePath(command v1alpha2.Command) string {
+	var commandType string
+	switch {
+	case command.Exec != nil:
+		commandType = "exec"
+	case command.Apply != nil:
+		commandType = "apply"
+	case command.Composite != nil:
+		commandType = "composite"
+	case command.Custom != nil:
+		commandType = "custom"
+	default:
+		return commandPath(command)
+	}
+	return fmt.Sprintf("%s/%s", commandPath(command), commandType)
+}
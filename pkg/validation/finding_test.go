@@ -0,0 +1,130 @@
+//
+//
+// Copyright Red Hat
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validation
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/devfile/api/v2/pkg/apis/workspaces/v1alpha2"
+	"github.com/devfile/api/v2/pkg/attributes"
+	"github.com/hashicorp/go-multierror"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFindings(t *testing.T) {
+
+	parentOverridesFromMainDevfile := attributes.Attributes{}.PutString(ImportSourceAttribute,
+		"uri: http://127.0.0.1:8080").PutString(ParentOverrideAttribute, "main devfile")
+
+	importedComponent := generateDummyContainerComponent("runtime", nil, []v1alpha2.Endpoint{generateDummyEndpoint("http", 8080)}, nil, v1alpha2.Annotation{}, false)
+	importedComponent.Attributes = parentOverridesFromMainDevfile
+
+	tests := []struct {
+		name        string
+		components  []v1alpha2.Component
+		wantFinding []Finding
+	}{
+		{
+			name: "Duplicate endpoint name in imported component",
+			components: []v1alpha2.Component{
+				generateDummyContainerComponent("tools", nil, []v1alpha2.Endpoint{generateDummyEndpoint("http", 8081)}, nil, v1alpha2.Annotation{}, false),
+				importedComponent,
+			},
+			wantFinding: []Finding{
+				{
+					RuleID: RuleEndpointDuplicateName,
+					Path:   "/components[name=runtime]/container/endpoints[name=http]",
+					Kind:   EndpointElement,
+					Key:    "http",
+					Provenance: &ImportProvenance{
+						ImportedFrom:       "uri: http://127.0.0.1:8080",
+						ParentOverrideFrom: "main devfile",
+					},
+				},
+			},
+		},
+		{
+			name: "Reserved env and missing volume mount",
+			components: []v1alpha2.Component{
+				generateDummyContainerComponent("tools", []v1alpha2.VolumeMount{{Name: "m2"}}, nil,
+					[]v1alpha2.EnvVar{{Name: EnvProjectsRoot, Value: "/projects"}}, v1alpha2.Annotation{}, false),
+			},
+			wantFinding: []Finding{
+				{
+					RuleID: RuleReservedEnv,
+					Path:   "/components[name=tools]/container/env[name=PROJECTS_ROOT]",
+					Kind:   EnvElement,
+					Key:    EnvProjectsRoot,
+				},
+				{
+					RuleID: RuleMissingVolumeMount,
+					Path:   "/components[name=tools]/container/volumeMounts[name=m2]",
+					Kind:   VolumeMountElement,
+					Key:    "m2",
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateComponents(tt.components).Err()
+
+			merr, ok := err.(*multierror.Error)
+			if !assert.True(t, ok, "Error should be a multierror") || !assert.Equal(t, len(tt.wantFinding), len(merr.Errors), "Error list length should match") {
+				return
+			}
+			for i := range merr.Errors {
+				var finding *Finding
+				if assert.True(t, errors.As(merr.Errors[i], &finding), "Error should be a finding") {
+					assert.Equal(t, tt.wantFinding[i].RuleID, finding.RuleID, "Rule id should match")
+					assert.Equal(t, tt.wantFinding[i].Path, finding.Path, "Path should match")
+					assert.Equal(t, tt.wantFinding[i].Kind, finding.Kind, "Kind should match")
+					assert.Equal(t, tt.wantFinding[i].Key, finding.Key, "Key should match")
+					assert.Equal(t, tt.wantFinding[i].Provenance, finding.Provenance, "Provenance should match")
+				}
+			}
+		})
+	}
+}
+
+func TestFindingWithoutError(t *testing.T) {
+	finding := &Finding{RuleID: "custom-rule", Kind: ComponentElement, Key: "component1"}
+
+	assert.Equal(t, "the validation rule custom-rule returned a finding without error", finding.Error(), "Error message should match")
+	assert.NoError(t, finding.Unwrap(), "Underlying error should be nil")
+}
+
+func TestFindingMarshalJSON(t *testing.T) {
+	finding := newFinding(RuleInvalidURI, errors.New("invalid URI for request"), ComponentElement, "k8s",
+		"/components[name=k8s]/kubernetes/uri",
+		attributes.Attributes{}.PutString(ImportSourceAttribute, "id: nodejs").PutString(PluginOverrideAttribute, "main devfile"))
+
+	data, err := json.Marshal(finding)
+	if assert.NoError(t, err) {
+		assert.JSONEq(t, `{
+			"ruleId": "invalid-uri",
+			"path": "/components[name=k8s]/kubernetes/uri",
+			"kind": "component",
+			"key": "k8s",
+			"severity": "error",
+			"provenance": {"importedFrom": "id: nodejs", "pluginOverrideFrom": "main devfile"},
+			"message": "invalid URI for request, imported from id: nodejs, in plugin overrides from main devfile"
+		}`, string(data))
+	}
+}
@@ -0,0 +1,143 @@
+//
+//
+// Copyright Red Hat
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validation
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/devfile/api/v2/pkg/apis/workspaces/v1alpha2"
+)
+
+const (
+	// imageNameMaxLength is the maximum length of the image name, ie; the domain and the repository path
+	imageNameMaxLength = 255
+
+	// latestTag is the tag used by the container runtimes for the untagged images
+	latestTag = "latest"
+)
+
+var (
+	// domainRegex matches the registry host, e.g. quay.io, localhost:5000 or [::1]:5000
+	domainRegex = `(?:(?:[a-zA-Z0-9]|[a-zA-Z0-9][a-zA-Z0-9-]*[a-zA-Z0-9])(?:\.(?:[a-zA-Z0-9]|[a-zA-Z0-9][a-zA-Z0-9-]*[a-zA-Z0-9]))*|\[(?:[a-fA-F0-9:]+)\])(?::[0-9]+)?`
+
+	// pathComponentRegex matches a repository path component, e.g. devfile or universal-developer-image
+	pathComponentRegex = `[a-z0-9]+(?:(?:[._]|__|[-]+)[a-z0-9]+)*`
+
+	// imageReferenceRegex matches the image reference grammar [domain/]path[:tag][@digest]
+	// example: quay.io/devfile/universal-developer-image:ubi8-latest@sha256:<64 hex digits>
+	imageReferenceRegex = regexp.MustCompile(`^((?:` + domainRegex + `/)?` + pathComponentRegex + `(?:/` + pathComponentRegex + `)*)` +
+		`(?::([\w][\w.-]{0,127}))?` +
+		`(?:@([A-Za-z][A-Za-z0-9]*(?:[-_+.][A-Za-z][A-Za-z0-9]*)*:[0-9a-fA-F]{32,}))?$`)
+
+	// unresolvedVariableRegex matches a global variable reference left after the variable substitution
+	unresolvedVariableRegex = regexp.MustCompile(`\{\{.*?\}\}`)
+)
+
+// ImagePolicy is the pinning policy of the container and image component images
+type ImagePolicy struct {
+	// MutableTag is the severity of the findings reported for the untagged or `:latest` images not pinned by digest,
+	// the check is disabled if empty
+	MutableTag Severity
+
+	// RequireDigest reports an error for the images not pinned by digest
+	RequireDigest bool
+}
+
+// imageReference is a parsed image reference
+type imageReference struct {
+	name   string
+	tag    string
+	digest string
+}
+
+// parseImageReference parses the image reference, an error is returned if the reference does not match the
+// image reference grammar [registry/]repository[:tag][@digest]
+func parseImageReference(image string) (*imageReference, error) {
+	if image == "" {
+		return nil, fmt.Errorf("the image reference is empty")
+	}
+	matches := imageReferenceRegex.FindStringSubmatch(image)
+	if matches == nil {
+		if strings.ToLower(image) != image && imageReferenceRegex.MatchString(strings.ToLower(image)) {
+			return nil, fmt.Errorf("the repository name must be lowercase")
+		}
+		return nil, fmt.Errorf("the image reference must be in the format [registry/]repository[:tag][@digest]")
+	}
+	if len(matches[1]) > imageNameMaxLength {
+		return nil, fmt.Errorf("the image name must not be longer than %d characters", imageNameMaxLength)
+	}
+
+	return &imageReference{name: matches[1], tag: matches[2], digest: matches[3]}, nil
+}
+
+// getComponentImage returns the image of a container or image component and its path, or an empty image
+// for the other component types
+func getComponentImage(component v1alpha2.Component) (image string, imagePath string) {
+	switch {
+	case component.Container != nil:
+		return component.Container.Image, fmt.Sprintf("%s/image", componentTypePath(component))
+	case component.Image != nil:
+		return component.Image.ImageName, fmt.Sprintf("%s/imageName", componentTypePath(component))
+	}
+	return "", ""
+}
+
+// validateImageReference checks the image of the container or image component matches the image reference grammar.
+// An image still referencing a global variable is skipped, the invalid variable reference is reported on its own.
+func validateImageReference(component v1alpha2.Component) (result ValidationResult) {
+	image, imagePath := getComponentImage(component)
+	if image == "" || unresolvedVariableRegex.MatchString(image) {
+		return result
+	}
+
+	if _, err := parseImageReference(image); err != nil {
+		imageErr := &InvalidImageReferenceError{componentName: component.Name, image: image, reason: err.Error()}
+		result.addError(newFinding(RuleImageReference, imageErr, ComponentElement, component.Name, imagePath, component.Attributes))
+	}
+
+	return result
+}
+
+// validateImagePolicy checks the container and image component images against the pinning policy, the images
+// with an invalid reference are skipped
+func validateImagePolicy(components []v1alpha2.Component, policy ImagePolicy) (result ValidationResult) {
+	for _, component := range components {
+		image, imagePath := getComponentImage(component)
+		if image == "" {
+			continue
+		}
+		reference, err := parseImageReference(image)
+		if err != nil || reference.digest != "" {
+			continue
+		}
+
+		if policy.MutableTag != "" && (reference.tag == "" || reference.tag == latestTag) {
+			finding := newFinding(RuleImageTag, &MutableImageTagError{componentName: component.Name, image: image},
+				ComponentElement, component.Name, imagePath, component.Attributes)
+			finding.Severity = policy.MutableTag
+			result.add(finding)
+		}
+		if policy.RequireDigest {
+			result.addError(newFinding(RuleImageDigest, &MissingImageDigestError{componentName: component.Name, image: image},
+				ComponentElement, component.Name, imagePath, component.Attributes))
+		}
+	}
+
+	return result
+}
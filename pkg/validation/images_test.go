@@ -0,0 +1,167 @@
+//
+//
+// Copyright Red Hat
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validation
+
+import (
+	"testing"
+
+	"github.com/devfile/api/v2/pkg/apis/workspaces/v1alpha2"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseImageReference(t *testing.T) {
+
+	digest := "sha256:0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef"
+
+	tests := []struct {
+		name       string
+		image      string
+		wantName   string
+		wantTag    string
+		wantDigest string
+		wantErr    string
+	}{
+		{
+			name:     "Repository only",
+			image:    "maven",
+			wantName: "maven",
+		},
+		{
+			name:     "Registry, repository and tag",
+			image:    "quay.io/devfile/universal-developer-image:ubi8-latest",
+			wantName: "quay.io/devfile/universal-developer-image",
+			wantTag:  "ubi8-latest",
+		},
+		{
+			name:     "Registry with port",
+			image:    "localhost:5000/backend:1.0",
+			wantName: "localhost:5000/backend",
+			wantTag:  "1.0",
+		},
+		{
+			name:       "Tag and digest",
+			image:      "quay.io/devfile/tools:1.0@" + digest,
+			wantName:   "quay.io/devfile/tools",
+			wantTag:    "1.0",
+			wantDigest: digest,
+		},
+		{
+			name:    "Uppercase repository",
+			image:   "quay.io/devfile/Tools:1.0",
+			wantErr: "the repository name must be lowercase",
+		},
+		{
+			name:    "Invalid tag",
+			image:   "quay.io/devfile/tools:-1.0",
+			wantErr: "the image reference must be in the format .*",
+		},
+		{
+			name:    "Invalid digest",
+			image:   "quay.io/devfile/tools@sha256:1234",
+			wantErr: "the image reference must be in the format .*",
+		},
+		{
+			name:    "Image with whitespaces",
+			image:   "quay.io/devfile/tools 1.0",
+			wantErr: "the image reference must be in the format .*",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reference, err := parseImageReference(tt.image)
+
+			if tt.wantErr != "" {
+				if assert.Error(t, err) {
+					assert.Regexp(t, tt.wantErr, err.Error(), "Error message should match")
+				}
+			} else if assert.NoError(t, err) {
+				assert.Equal(t, tt.wantName, reference.name, "Image name should match")
+				assert.Equal(t, tt.wantTag, reference.tag, "Image tag should match")
+				assert.Equal(t, tt.wantDigest, reference.digest, "Image digest should match")
+			}
+		})
+	}
+}
+
+func TestValidateImagePolicy(t *testing.T) {
+
+	generateImageComponents := func(image, imageName string) []v1alpha2.Component {
+		container := generateDummyContainerComponent("container1", nil, nil, nil, v1alpha2.Annotation{}, false)
+		container.Container.Image = image
+		imageComponent := generateDummyImageComponent("image1", v1alpha2.DockerfileSrc{})
+		imageComponent.Image.ImageName = imageName
+		return []v1alpha2.Component{container, imageComponent}
+	}
+
+	digest := "sha256:0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef"
+
+	containerMutableTagErr := "the image \"maven:latest\" of component container1 is untagged or uses the latest tag"
+	imageMutableTagErr := "the image \"backend\" of component image1 is untagged or uses the latest tag"
+	containerDigestErr := "the image \"maven:3.8\" of component container1 must be pinned by digest"
+
+	tests := []struct {
+		name        string
+		components  []v1alpha2.Component
+		policy      ImagePolicy
+		wantErr     []string
+		wantWarning []string
+	}{
+		{
+			name:       "No policy",
+			components: generateImageComponents("maven:latest", "backend"),
+		},
+		{
+			name:        "Warning on untagged and latest images",
+			components:  generateImageComponents("maven:latest", "backend"),
+			policy:      ImagePolicy{MutableTag: WarningSeverity},
+			wantWarning: []string{containerMutableTagErr, imageMutableTagErr},
+		},
+		{
+			name:       "Error on untagged and latest images",
+			components: generateImageComponents("maven:latest", "backend"),
+			policy:     ImagePolicy{MutableTag: ErrorSeverity},
+			wantErr:    []string{containerMutableTagErr, imageMutableTagErr},
+		},
+		{
+			name:       "Latest image pinned by digest",
+			components: generateImageComponents("maven:latest@"+digest, "backend:1.0@"+digest),
+			policy:     ImagePolicy{MutableTag: ErrorSeverity, RequireDigest: true},
+		},
+		{
+			name:       "Require digest",
+			components: generateImageComponents("maven:3.8", "backend:1.0@"+digest),
+			policy:     ImagePolicy{RequireDigest: true},
+			wantErr:    []string{containerDigestErr},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := validateImagePolicy(tt.components, tt.policy)
+
+			if assert.Equal(t, len(tt.wantErr), len(result.Errors), "Error list length should match") {
+				for i := 0; i < len(result.Errors); i++ {
+					assert.Regexp(t, tt.wantErr[i], result.Errors[i].Error(), "Error message should match")
+				}
+			}
+			if assert.Equal(t, len(tt.wantWarning), len(result.Warnings), "Warning list length should match") {
+				for i := 0; i < len(result.Warnings); i++ {
+					assert.Regexp(t, tt.wantWarning[i], result.Warnings[i].Error(), "Warning message should match")
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,137 @@
+//
+//
+// Copyright Red Hat
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validation
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/devfile/api/v2/pkg/apis/workspaces/v1alpha2"
+	attributesAPI "github.com/devfile/api/v2/pkg/attributes"
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+// importVersionRegex matches the version of an id import reference, either latest or a semantic version of the stack
+var importVersionRegex = regexp.MustCompile(`^(latest|[1-9]\.[0-9]+\.[0-9]+(-[0-9a-z-]+(\.[0-9a-z-]+)*)?(\+[0-9A-Za-z-]+(\.[0-9A-Za-z-]+)*)?)$`)
+
+// importReferenceTypes are the import reference sources, in the order they are reported
+var importReferenceTypes = []v1alpha2.ImportReferenceType{
+	v1alpha2.UriImportReferenceType,
+	v1alpha2.IdImportReferenceType,
+	v1alpha2.KubernetesImportReferenceType,
+}
+
+// importReferenceElement is the devfile element holding an import reference, i.e. the parent or a plugin component
+type importReferenceElement struct {
+	// description is the human readable description of the element, e.g. parent or plugin component java
+	description string
+	path        string
+	kind        ElementKind
+	key         string
+	attributes  attributesAPI.Attributes
+}
+
+// ValidateParent validates the parent import reference
+// 1. the parent must reference only one of uri, id or kubernetes, matching the importReferenceType if set
+// 2. the uri and registryUrl need to be in valid URI format
+// 3. the registryUrl and version can only be used with an id import reference, the version must be latest or a semantic version
+// 4. the kubernetes import reference name must be a valid DNS-1123 subdomain and its namespace a valid DNS-1123 label
+//
+// The parent overrides share the validation rules of the main devfile content, they are validated in the flattened devfile
+func ValidateParent(parent *v1alpha2.Parent) ValidationResult {
+	if parent == nil {
+		return ValidationResult{}
+	}
+	return DefaultRuleRegistry.filter(validateParent(parent))
+}
+
+func validateParent(parent *v1alpha2.Parent) ValidationResult {
+	return validateImportReference(parent.ImportReference, importReferenceElement{description: "parent", path: "/parent", kind: ParentElement})
+}
+
+// validatePluginImportReference validates the import reference of the plugin component with the rules of the parent import reference
+func validatePluginImportReference(component v1alpha2.Component) ValidationResult {
+	return validateImportReference(component.Plugin.ImportReference, importReferenceElement{
+		description: fmt.Sprintf("plugin component %s", component.Name),
+		path:        componentTypePath(component),
+		kind:        ComponentElement,
+		key:         component.Name,
+		attributes:  component.Attributes,
+	})
+}
+
+// validateImportReference checks the import reference of the parent or of a plugin component
+func validateImportReference(reference v1alpha2.ImportReference, element importReferenceElement) (result ValidationResult) {
+	addError := func(err error, field string) {
+		result.addError(newFinding(RuleImportReference, err, element.kind, element.key, fmt.Sprintf("%s/%s", element.path, field), element.attributes))
+	}
+
+	referencedSources := map[v1alpha2.ImportReferenceType]bool{
+		v1alpha2.UriImportReferenceType:        reference.Uri != "",
+		v1alpha2.IdImportReferenceType:         reference.Id != "",
+		v1alpha2.KubernetesImportReferenceType: reference.Kubernetes != nil,
+	}
+	var sources []string
+	for _, referenceType := range importReferenceTypes {
+		if referencedSources[referenceType] {
+			sources = append(sources, strings.ToLower(string(referenceType)))
+		}
+	}
+	if len(sources) > 1 {
+		result.addError(newFinding(RuleImportReference, &MultipleImportReferenceSourcesError{element: element.description, sources: sources},
+			element.kind, element.key, element.path, element.attributes))
+	}
+	if referenceType := reference.ImportReferenceType; referenceType != "" && !referencedSources[referenceType] {
+		addError(&ImportReferenceTypeMismatchError{element: element.description, referenceType: referenceType}, "importReferenceType")
+	}
+
+	if reference.Uri != "" {
+		result.Merge(validateURIField(reference.Uri, element.kind, element.key, fmt.Sprintf("%s/uri", element.path), element.attributes))
+	}
+
+	if reference.RegistryUrl != "" {
+		if reference.Id == "" {
+			addError(&IdOnlyImportFieldError{element: element.description, field: "registryUrl"}, "registryUrl")
+		}
+		result.Merge(validateURIField(reference.RegistryUrl, element.kind, element.key, fmt.Sprintf("%s/registryUrl", element.path), element.attributes))
+	}
+
+	if reference.Version != "" {
+		if reference.Id == "" {
+			addError(&IdOnlyImportFieldError{element: element.description, field: "version"}, "version")
+		}
+		if !importVersionRegex.MatchString(reference.Version) {
+			addError(&InvalidImportVersionError{element: element.description, version: reference.Version}, "version")
+		}
+	}
+
+	if kubernetes := reference.Kubernetes; kubernetes != nil {
+		if errs := validation.IsDNS1123Subdomain(kubernetes.Name); len(errs) > 0 {
+			addError(&InvalidKubernetesImportReferenceError{element: element.description, field: "name", value: kubernetes.Name, reason: strings.Join(errs, ", ")},
+				"kubernetes/name")
+		}
+		if kubernetes.Namespace != "" {
+			if errs := validation.IsDNS1123Label(kubernetes.Namespace); len(errs) > 0 {
+				addError(&InvalidKubernetesImportReferenceError{element: element.description, field: "namespace", value: kubernetes.Namespace, reason: strings.Join(errs, ", ")},
+					"kubernetes/namespace")
+			}
+		}
+	}
+
+	return result
+}
@@ -0,0 +1,139 @@
+//
+//
+// Copyright Red Hat
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validation
+
+import (
+	"testing"
+
+	"github.com/devfile/api/v2/pkg/apis/workspaces/v1alpha2"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateParent(t *testing.T) {
+
+	multipleSourcesErr := "parent must reference only one of uri, id or kubernetes, found uri, id"
+	typeMismatchErr := "parent has importReferenceType Kubernetes, but does not define kubernetes"
+	registryUrlWithoutIdErr := "parent defines registryUrl, which can only be used with an id import reference"
+	versionWithoutIdErr := "parent defines version, which can only be used with an id import reference"
+	invalidVersionErr := "version 0.1 of parent is invalid, it must be latest or a semantic version, e.g. 1.0.0"
+	invalidURIErr := ".*invalid URI for request"
+	invalidKubernetesNameErr := "kubernetes name \"My_Template\" of parent is invalid: .*"
+	invalidKubernetesNamespaceErr := "kubernetes namespace \"my.namespace\" of parent is invalid: .*"
+
+	tests := []struct {
+		name      string
+		reference v1alpha2.ImportReference
+		wantErr   []string
+	}{
+		{
+			name: "Valid id import reference",
+			reference: v1alpha2.ImportReference{
+				ImportReferenceUnion: v1alpha2.ImportReferenceUnion{ImportReferenceType: v1alpha2.IdImportReferenceType, Id: "nodejs"},
+				RegistryUrl:          "https://registry.devfile.io",
+				Version:              "2.1.0",
+			},
+		},
+		{
+			name: "Valid uri import reference",
+			reference: v1alpha2.ImportReference{
+				ImportReferenceUnion: v1alpha2.ImportReferenceUnion{Uri: "https://example.com/devfile.yaml"},
+			},
+		},
+		{
+			name: "Valid kubernetes import reference",
+			reference: v1alpha2.ImportReference{
+				ImportReferenceUnion: v1alpha2.ImportReferenceUnion{
+					Kubernetes: &v1alpha2.KubernetesCustomResourceImportReference{Name: "my-template", Namespace: "my-namespace"},
+				},
+			},
+		},
+		{
+			name: "Multiple import reference sources",
+			reference: v1alpha2.ImportReference{
+				ImportReferenceUnion: v1alpha2.ImportReferenceUnion{Uri: "https://example.com/devfile.yaml", Id: "nodejs"},
+			},
+			wantErr: []string{multipleSourcesErr},
+		},
+		{
+			name: "Import reference type not matching the source",
+			reference: v1alpha2.ImportReference{
+				ImportReferenceUnion: v1alpha2.ImportReferenceUnion{ImportReferenceType: v1alpha2.KubernetesImportReferenceType, Id: "nodejs"},
+			},
+			wantErr: []string{typeMismatchErr},
+		},
+		{
+			name: "Registry url and version without id",
+			reference: v1alpha2.ImportReference{
+				ImportReferenceUnion: v1alpha2.ImportReferenceUnion{Uri: "https://example.com/devfile.yaml"},
+				RegistryUrl:          "https://registry.devfile.io",
+				Version:              "latest",
+			},
+			wantErr: []string{registryUrlWithoutIdErr, versionWithoutIdErr},
+		},
+		{
+			name: "Invalid version and registry url",
+			reference: v1alpha2.ImportReference{
+				ImportReferenceUnion: v1alpha2.ImportReferenceUnion{Id: "nodejs"},
+				RegistryUrl:          "http//registry",
+				Version:              "0.1",
+			},
+			wantErr: []string{invalidURIErr, invalidVersionErr},
+		},
+		{
+			name: "Invalid uri",
+			reference: v1alpha2.ImportReference{
+				ImportReferenceUnion: v1alpha2.ImportReferenceUnion{Uri: "http//wronguri"},
+			},
+			wantErr: []string{invalidURIErr},
+		},
+		{
+			name: "Invalid kubernetes name and namespace",
+			reference: v1alpha2.ImportReference{
+				ImportReferenceUnion: v1alpha2.ImportReferenceUnion{
+					Kubernetes: &v1alpha2.KubernetesCustomResourceImportReference{Name: "My_Template", Namespace: "my.namespace"},
+				},
+			},
+			wantErr: []string{invalidKubernetesNameErr, invalidKubernetesNamespaceErr},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := ValidateParent(&v1alpha2.Parent{ImportReference: tt.reference})
+
+			if assert.Equal(t, len(tt.wantErr), len(result.Errors), "Error list length should match") {
+				for i := 0; i < len(result.Errors); i++ {
+					assert.Regexp(t, tt.wantErr[i], result.Errors[i].Error(), "Error message should match")
+				}
+			}
+		})
+	}
+}
+
+func TestValidatePluginImportReference(t *testing.T) {
+
+	component := generateDummyPluginComponent("java", "https://registry.devfile.io", nil)
+	component.Plugin.Id = ""
+	component.Plugin.Uri = "https://example.com/devfile.yaml"
+
+	result := validatePluginImportReference(component)
+
+	if assert.Equal(t, 1, len(result.Errors), "Error list length should match") {
+		assert.Equal(t, "plugin component java defines registryUrl, which can only be used with an id import reference", result.Errors[0].Error())
+		assert.Equal(t, "/components[name=java]/plugin/registryUrl", result.Errors[0].Path)
+		assert.Equal(t, RuleImportReference, result.Errors[0].RuleID)
+	}
+}
@@ -0,0 +1,211 @@
+//
+//
+// Copyright Red Hat
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validation
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"strings"
+	"sync"
+
+	"github.com/devfile/api/v2/pkg/apis/workspaces/v1alpha2"
+)
+
+const (
+	// componentCacheKind is the cache kind of the checks of a component content
+	componentCacheKind = "component"
+
+	// commandCacheKind is the cache kind of the checks of a command content and references
+	commandCacheKind = "command"
+)
+
+// IncrementalValidator validates successive versions of a devfile, e.g. on every edit of the devfile in an editor.
+// The results of the checks of each component and command are cached, keyed by a content hash of the element and of the
+// elements it references. A revalidation only reruns the checks of the changed elements and of the elements referencing them,
+// along with the checks spanning all the elements, e.g. the duplicate keys, endpoint ports or volume references.
+//
+// The results are the same as the ones of ValidateDevfile. An IncrementalValidator is safe for concurrent use, the validations
+// are serialized.
+type IncrementalValidator struct {
+	mutex   sync.Mutex
+	options ValidationOptions
+	cache   *validationCache
+}
+
+// NewIncrementalValidator returns an incremental validator validating the devfiles with the given options
+func NewIncrementalValidator(options ValidationOptions) *IncrementalValidator {
+	return &IncrementalValidator{options: options, cache: newValidationCache()}
+}
+
+// ValidateDevfile validates the devfile as ValidateDevfile, reusing the results of the unchanged elements of the previously
+// validated devfile. The cached results of the elements removed since the previous validation are dropped.
+func (v *IncrementalValidator) ValidateDevfile(devfile *v1alpha2.Devfile) ValidationResult {
+	v.mutex.Lock()
+	defer v.mutex.Unlock()
+
+	v.cache.begin()
+	defer v.cache.end()
+	return validateDevfile(devfile, v.options, v.cache)
+}
+
+// validationCache caches the results of the element checks of the current and previous validations
+type validationCache struct {
+	previous map[string]ValidationResult
+	current  map[string]ValidationResult
+
+	// hits and misses are the number of cached and computed results of the current validation
+	hits   int
+	misses int
+}
+
+// newValidationCache returns an empty validation cache
+func newValidationCache() *validationCache {
+	return &validationCache{previous: make(map[string]ValidationResult), current: make(map[string]ValidationResult)}
+}
+
+// begin starts a validation, the results cached by the previous validation can be reused
+func (c *validationCache) begin() {
+	c.hits = 0
+	c.misses = 0
+}
+
+// end completes a validation, only the results used by the validation are kept for the next one
+func (c *validationCache) end() {
+	c.previous = c.current
+	c.current = make(map[string]ValidationResult)
+}
+
+// validate returns the cached result of the checks of the elements, or runs the checks and caches their result.
+// The checks are run without cache if the cache is nil or if the elements cannot be hashed, the elements are only
+// computed if the cache is set
+func (c *validationCache) validate(kind string, validateFunc func() ValidationResult, getElements func() []interface{}) ValidationResult {
+	if c == nil {
+		return validateFunc()
+	}
+	hash, err := getContentHash(getElements())
+	if err != nil {
+		return validateFunc()
+	}
+
+	key := kind + ":" + hash
+	result, ok := c.current[key]
+	if !ok {
+		result, ok = c.previous[key]
+	}
+	if ok {
+		c.hits++
+	} else {
+		c.misses++
+		result = validateFunc()
+	}
+	c.current[key] = result
+	return copyResult(result)
+}
+
+// getContentHash returns the hex encoded SHA-256 hash of the JSON serialization of the elements
+func getContentHash(elements []interface{}) (string, error) {
+	content, err := json.Marshal(elements)
+	if err != nil {
+		return "", err
+	}
+	hash := sha256.Sum256(content)
+	return hex.EncodeToString(hash[:]), nil
+}
+
+// copyResult returns a copy of the validation result, the findings are copied so the cached findings are not altered
+// by the callers, e.g. by PromoteWarnings
+func copyResult(result ValidationResult) ValidationResult {
+	copyFindings := func(findings []*Finding) []*Finding {
+		var copies []*Finding
+		for _, finding := range findings {
+			findingCopy := *finding
+			copies = append(copies, &findingCopy)
+		}
+		return copies
+	}
+	return ValidationResult{Errors: copyFindings(result.Errors), Warnings: copyFindings(result.Warnings), Info: copyFindings(result.Info)}
+}
+
+// getComponentTypePaths returns the type paths of the components by name, e.g. /components[name=runtime]/container
+func getComponentTypePaths(components []v1alpha2.Component) map[string][]string {
+	componentTypePaths := make(map[string][]string, len(components))
+	for _, component := range components {
+		componentTypePaths[component.Name] = append(componentTypePaths[component.Name], componentTypePath(component))
+	}
+	return componentTypePaths
+}
+
+// getCommandCacheElements returns the elements the checks of the command depend on: the command and its index, the name and
+// type of the component of an exec or apply command, and the subcommands of a composite command, recursively. If a reference
+// is not resolved, the component names and command ids the suggestions are picked from are added
+func getCommandCacheElements(commandIndex int, command v1alpha2.Command, commands []v1alpha2.Command,
+	commandMap map[string]v1alpha2.Command, components []v1alpha2.Component, componentTypePaths map[string][]string) []interface{} {
+	elements := []interface{}{commandIndex, command}
+	dependencies, resolved := getCommandDependencies(command, commandMap, componentTypePaths, map[string]bool{strings.ToLower(command.Id): true})
+	elements = append(elements, dependencies...)
+	if !resolved {
+		var componentNames, commandIds []string
+		for _, component := range components {
+			componentNames = append(componentNames, componentTypePath(component))
+		}
+		for _, devfileCommand := range commands {
+			commandIds = append(commandIds, devfileCommand.Id)
+		}
+		elements = append(elements, componentNames, commandIds)
+	}
+	return elements
+}
+
+// getCommandDependencies returns the elements referenced by the command, and false if a reference is not resolved,
+// where visited is the set of the lowercase ids of the commands already walked through
+func getCommandDependencies(command v1alpha2.Command, commandMap map[string]v1alpha2.Command, componentTypePaths map[string][]string,
+	visited map[string]bool) (dependencies []interface{}, resolved bool) {
+	resolved = true
+
+	switch {
+	case command.Composite != nil:
+		for _, subCommandId := range command.Composite.Commands {
+			if visited[strings.ToLower(subCommandId)] {
+				continue
+			}
+			visited[strings.ToLower(subCommandId)] = true
+			subCommand, ok := commandMap[strings.ToLower(subCommandId)]
+			if !ok {
+				resolved = false
+				continue
+			}
+			subDependencies, subResolved := getCommandDependencies(subCommand, commandMap, componentTypePaths, visited)
+			dependencies = append(append(dependencies, subCommand), subDependencies...)
+			resolved = resolved && subResolved
+		}
+	case command.Exec != nil || command.Apply != nil:
+		var componentName string
+		if command.Exec != nil {
+			componentName = command.Exec.Component
+		} else {
+			componentName = command.Apply.Component
+		}
+		// the command checks only depend on the name and type of the component
+		for _, typePath := range componentTypePaths[componentName] {
+			dependencies = append(dependencies, typePath)
+		}
+		resolved = len(componentTypePaths[componentName]) > 0
+	}
+
+	return dependencies, resolved
+}
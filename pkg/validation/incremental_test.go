@@ -0,0 +1,193 @@
+//
+//
+// Copyright Red Hat
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validation
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/devfile/api/v2/pkg/apis/workspaces/v1alpha2"
+	"github.com/stretchr/testify/assert"
+)
+
+// generateLargeDevfile returns a devfile with the given number of container, kubernetes and volume components, and as many
+// exec and composite commands, for testing and benchmarking
+func generateLargeDevfile(size int) *v1alpha2.Devfile {
+	var components []v1alpha2.Component
+	var commands []v1alpha2.Command
+	for i := 0; i < size; i++ {
+		volumeName := fmt.Sprintf("volume%d", i)
+		container := generateDummyContainerComponent(fmt.Sprintf("container%d", i),
+			[]v1alpha2.VolumeMount{{Name: volumeName, Path: fmt.Sprintf("/cache/%d", i)}},
+			[]v1alpha2.Endpoint{{Name: fmt.Sprintf("http%d", i), TargetPort: 1000 + i, Path: "/health"}},
+			[]v1alpha2.EnvVar{{Name: "GOPATH", Value: "/go"}, {Name: "GOCACHE", Value: "/go/cache"}},
+			v1alpha2.Annotation{}, true)
+		container.Container.Image = fmt.Sprintf("quay.io/devfile/tools%d:1.0", i)
+		container.Container.MemoryLimit = "512Mi"
+		container.Container.MemoryRequest = "256Mi"
+
+		kubernetes := generateDummyKubernetesComponent(fmt.Sprintf("kubernetes%d", i), nil, "")
+		kubernetes.Kubernetes.Inlined = fmt.Sprintf(`apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: deployment%d
+spec:
+  template:
+    spec:
+      containers:
+        - name: runtime
+          image: quay.io/devfile/runtime:1.0
+          ports:
+            - containerPort: 8080
+`, i)
+
+		components = append(components, container, kubernetes, generateDummyVolumeComponent(volumeName, "1Gi"))
+		commands = append(commands,
+			generateDummyExecCommand(fmt.Sprintf("exec%d", i), container.Name, nil),
+			generateDummyCompositeCommand(fmt.Sprintf("composite%d", i), []string{fmt.Sprintf("exec%d", i)}, nil))
+	}
+
+	return generateDummyDevfile("2.2.0", nil, components, commands, nil, nil)
+}
+
+// copyDevfile returns a deep copy of the devfile
+func copyDevfile(devfile *v1alpha2.Devfile) *v1alpha2.Devfile {
+	return &v1alpha2.Devfile{DevfileHeader: devfile.DevfileHeader, DevWorkspaceTemplateSpec: *devfile.DevWorkspaceTemplateSpec.DeepCopy()}
+}
+
+// getFindingMessages returns the messages of the findings of the validation result
+func getFindingMessages(result ValidationResult) (messages []string) {
+	for _, findings := range [][]*Finding{result.Errors, result.Warnings, result.Info} {
+		for _, finding := range findings {
+			messages = append(messages, fmt.Sprintf("%s %s %s", finding.Severity, finding.Path, finding.Error()))
+		}
+		messages = append(messages, "")
+	}
+	return messages
+}
+
+func TestIncrementalValidator(t *testing.T) {
+
+	validator := NewIncrementalValidator(ValidationOptions{})
+	devfile := generateLargeDevfile(3)
+
+	tests := []struct {
+		name       string
+		edit       func(devfile *v1alpha2.Devfile)
+		wantHits   int
+		wantMisses int
+	}{
+		{
+			name:       "First validation",
+			edit:       func(devfile *v1alpha2.Devfile) {},
+			wantMisses: 15,
+		},
+		{
+			name:     "Unchanged devfile",
+			edit:     func(devfile *v1alpha2.Devfile) {},
+			wantHits: 15,
+		},
+		{
+			name: "Container env edit",
+			edit: func(devfile *v1alpha2.Devfile) {
+				devfile.Components[0].Container.Env = append(devfile.Components[0].Container.Env, v1alpha2.EnvVar{Name: "PROJECT_SOURCE", Value: "/src"})
+			},
+			wantHits:   14,
+			wantMisses: 1,
+		},
+		{
+			name: "Container rename breaks the referencing commands",
+			edit: func(devfile *v1alpha2.Devfile) {
+				devfile.Components[3].Name = "contaner1"
+			},
+			wantHits:   12,
+			wantMisses: 3,
+		},
+		{
+			name: "Exec command edit invalidates the referencing composite command",
+			edit: func(devfile *v1alpha2.Devfile) {
+				devfile.Commands[4].Exec.Env = []v1alpha2.EnvVar{{Name: "1INVALID", Value: "value"}}
+			},
+			wantHits:   13,
+			wantMisses: 2,
+		},
+		{
+			name: "Component removal changes the suggestions of the broken commands",
+			edit: func(devfile *v1alpha2.Devfile) {
+				devfile.Components = devfile.Components[:len(devfile.Components)-1]
+			},
+			wantHits:   12,
+			wantMisses: 2,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.edit(devfile)
+
+			result := validator.ValidateDevfile(copyDevfile(devfile))
+			assert.Equal(t, tt.wantHits, validator.cache.hits, "Cache hits should match")
+			assert.Equal(t, tt.wantMisses, validator.cache.misses, "Cache misses should match")
+			assert.Equal(t, getFindingMessages(ValidateDevfile(copyDevfile(devfile), ValidationOptions{})), getFindingMessages(result),
+				"Incremental validation result should match the full validation result")
+		})
+	}
+}
+
+func TestIncrementalValidatorCachedFindings(t *testing.T) {
+
+	validator := NewIncrementalValidator(ValidationOptions{})
+	devfile := generateLargeDevfile(1)
+	devfile.Components[0].Container.Image = "quay.io/devfile/tools:latest"
+	devfile.Components[0].Container.Endpoints[0].Exposure = v1alpha2.NoneEndpointExposure
+	devfile.Components[0].Container.Endpoints[0].Annotations = map[string]string{"nginx.ingress.kubernetes.io/rewrite-target": "/"}
+
+	result := validator.ValidateDevfile(copyDevfile(devfile))
+	if assert.Equal(t, 1, len(result.Warnings), "Warning list length should match") {
+		result.PromoteWarnings()
+	}
+
+	// promoting the warnings of a result does not alter the cached findings
+	result = validator.ValidateDevfile(copyDevfile(devfile))
+	if assert.Equal(t, 1, len(result.Warnings), "Warning list length should match") {
+		assert.Equal(t, WarningSeverity, result.Warnings[0].Severity, "Warning severity should match")
+	}
+}
+
+// benchmarkDevfileSize is the number of each kind of components and commands of the benchmarked devfile
+const benchmarkDevfileSize = 200
+
+// BenchmarkValidateDevfile validates a large devfile from scratch after each edit of a container env
+func BenchmarkValidateDevfile(b *testing.B) {
+	devfile := generateLargeDevfile(benchmarkDevfileSize)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		devfile.Components[0].Container.Env[0].Value = fmt.Sprintf("/go/%d", i)
+		ValidateDevfile(devfile, ValidationOptions{})
+	}
+}
+
+// BenchmarkIncrementalValidator revalidates a large devfile after each edit of a container env
+func BenchmarkIncrementalValidator(b *testing.B) {
+	devfile := generateLargeDevfile(benchmarkDevfileSize)
+	validator := NewIncrementalValidator(ValidationOptions{})
+	validator.ValidateDevfile(devfile)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		devfile.Components[0].Container.Env[0].Value = fmt.Sprintf("/go/%d", i)
+		validator.ValidateDevfile(devfile)
+	}
+}
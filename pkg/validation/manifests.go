@@ -0,0 +1,170 @@
+//
+//
+// Copyright Red Hat
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validation
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/devfile/api/v2/pkg/apis/workspaces/v1alpha2"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/util/yaml"
+)
+
+// podSpecPaths are the paths of the pod spec in the supported Kubernetes and OpenShift workloads:
+// Pod, the workloads with a pod template, e.g. Deployment or DeploymentConfig, and CronJob
+var podSpecPaths = [][]string{
+	{"spec"},
+	{"spec", "template", "spec"},
+	{"spec", "jobTemplate", "spec", "template", "spec"},
+}
+
+// validateInlinedManifest checks if the inlined manifest of a kubernetes or openshift component
+//  1. can be parsed as a single or multi-document YAML
+//  2. defines the apiVersion, kind and metadata.name of each object
+//  3. exposes the target ports of the component endpoints with a Service or a container port
+//
+// The manifest still referencing a global variable is skipped, it cannot be parsed before the variable substitution
+// and the invalid variable reference is reported on its own.
+func validateInlinedManifest(component v1alpha2.Component, inlined string, endpoints []v1alpha2.Endpoint) (result ValidationResult) {
+	if unresolvedVariableRegex.MatchString(inlined) {
+		return result
+	}
+	inlinedPath := fmt.Sprintf("%s/inlined", componentTypePath(component))
+
+	objects, err := parseManifest(inlined)
+	if err != nil {
+		manifestErr := &InvalidManifestError{componentName: component.Name, reason: err.Error()}
+		result.addError(newFinding(RuleManifestParse, manifestErr, ComponentElement, component.Name, inlinedPath, component.Attributes))
+		return result
+	}
+
+	exposedPorts := make(map[int]bool)
+	for i, object := range objects {
+		for _, missingField := range getMissingManifestFields(object) {
+			missingFieldErr := &MissingManifestFieldError{componentName: component.Name, document: i + 1, field: missingField}
+			result.addError(newFinding(RuleManifestField, missingFieldErr, ComponentElement, component.Name, inlinedPath, component.Attributes))
+		}
+		for _, port := range getExposedPorts(object) {
+			exposedPorts[port] = true
+		}
+	}
+
+	for _, endpoint := range endpoints {
+		if !exposedPorts[endpoint.TargetPort] {
+			unexposedErr := &UnexposedEndpointPortError{componentName: component.Name, endpointName: endpoint.Name, targetPort: endpoint.TargetPort}
+			result.addError(newFinding(RuleManifestEndpointPort, unexposedErr, EndpointElement, endpoint.Name,
+				fmt.Sprintf("%s/endpoints[name=%s]/targetPort", componentTypePath(component), endpoint.Name), component.Attributes))
+		}
+	}
+
+	return result
+}
+
+// parseManifest parses the single or multi-document YAML manifest into unstructured objects, empty documents are skipped
+func parseManifest(manifest string) ([]*unstructured.Unstructured, error) {
+	var objects []*unstructured.Unstructured
+
+	decoder := yaml.NewYAMLOrJSONDecoder(strings.NewReader(manifest), 4096)
+	for {
+		var object map[string]interface{}
+		if err := decoder.Decode(&object); err != nil {
+			if err == io.EOF {
+				break
+			}
+			return nil, err
+		}
+		if len(object) == 0 {
+			continue
+		}
+		objects = append(objects, &unstructured.Unstructured{Object: object})
+	}
+
+	return objects, nil
+}
+
+// getMissingManifestFields returns the required fields missing in the manifest object
+func getMissingManifestFields(object *unstructured.Unstructured) (missingFields []string) {
+	if object.GetAPIVersion() == "" {
+		missingFields = append(missingFields, "apiVersion")
+	}
+	if object.GetKind() == "" {
+		missingFields = append(missingFields, "kind")
+	}
+	if object.GetName() == "" {
+		missingFields = append(missingFields, "metadata.name")
+	}
+	return missingFields
+}
+
+// getExposedPorts returns the target ports of a Service, or the container ports of a workload
+func getExposedPorts(object *unstructured.Unstructured) (ports []int) {
+	if object.GetKind() == "Service" {
+		servicePorts, _, _ := unstructured.NestedSlice(object.Object, "spec", "ports")
+		for _, servicePort := range servicePorts {
+			servicePortMap, ok := servicePort.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			// the target port defaults to the service port, a named target port refers to a container port
+			// exposed by the workload and is resolved with the container ports
+			if targetPort, ok := servicePortMap["targetPort"]; ok {
+				if port, ok := toPort(targetPort); ok {
+					ports = append(ports, port)
+				}
+			} else if port, ok := toPort(servicePortMap["port"]); ok {
+				ports = append(ports, port)
+			}
+		}
+		return ports
+	}
+
+	for _, podSpecPath := range podSpecPaths {
+		containers, _, _ := unstructured.NestedSlice(object.Object, append(podSpecPath, "containers")...)
+		for _, container := range containers {
+			containerMap, ok := container.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			containerPorts, _, _ := unstructured.NestedSlice(containerMap, "ports")
+			for _, containerPort := range containerPorts {
+				containerPortMap, ok := containerPort.(map[string]interface{})
+				if !ok {
+					continue
+				}
+				if port, ok := toPort(containerPortMap["containerPort"]); ok {
+					ports = append(ports, port)
+				}
+			}
+		}
+	}
+	return ports
+}
+
+// toPort returns the port number of a decoded manifest value, named ports are not port numbers
+func toPort(value interface{}) (int, bool) {
+	switch port := value.(type) {
+	case int64:
+		return int(port), true
+	case float64:
+		return int(port), true
+	case int:
+		return port, true
+	}
+	return 0, false
+}
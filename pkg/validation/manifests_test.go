@@ -0,0 +1,134 @@
+//
+//
+// Copyright Red Hat
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validation
+
+import (
+	"testing"
+
+	"github.com/devfile/api/v2/pkg/apis/workspaces/v1alpha2"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateInlinedManifest(t *testing.T) {
+
+	deploymentManifest := `apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: backend
+spec:
+  template:
+    spec:
+      containers:
+        - name: backend
+          image: quay.io/devfile/backend:1.0
+          ports:
+            - containerPort: 8080
+`
+	serviceManifest := `apiVersion: v1
+kind: Service
+metadata:
+  name: backend
+spec:
+  ports:
+    - port: 80
+      targetPort: 3000
+    - port: 9090
+`
+	variableManifest := `apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: {{COMPONENT_NAME}}
+spec:
+  template:
+    spec:
+      containers:
+        - name: {{COMPONENT_NAME}}
+          image: "{{CONTAINER_IMAGE}}"
+`
+	missingFieldsManifest := `apiVersion: v1
+kind: Service
+metadata:
+  name: backend
+---
+kind: ConfigMap
+metadata:
+  labels:
+    app: backend
+`
+
+	parseErr := "unable to parse the inlined manifest of component component1: .*"
+	missingAPIVersionErr := "the inlined manifest of component component1 is missing apiVersion in document 2"
+	missingNameErr := "the inlined manifest of component component1 is missing metadata.name in document 2"
+	unexposedPortErr := "endpoint url1 targetPort 8081 is not exposed by a Service or a container port in the inlined manifest of component component1"
+
+	tests := []struct {
+		name      string
+		inlined   string
+		endpoints []v1alpha2.Endpoint
+		wantErr   []string
+	}{
+		{
+			name:      "Valid deployment manifest exposing the endpoint container port",
+			inlined:   deploymentManifest,
+			endpoints: []v1alpha2.Endpoint{generateDummyEndpoint("url1", 8080)},
+		},
+		{
+			name:      "Valid multi-document manifest exposing the endpoint target ports with a Service",
+			inlined:   deploymentManifest + "---\n" + serviceManifest + "---\n",
+			endpoints: []v1alpha2.Endpoint{generateDummyEndpoint("url1", 3000), generateDummyEndpoint("url2", 9090), generateDummyEndpoint("url3", 8080)},
+		},
+		{
+			name:    "Valid JSON manifest",
+			inlined: `{"apiVersion": "v1", "kind": "ConfigMap", "metadata": {"name": "config"}}`,
+		},
+		{
+			name:    "Invalid YAML manifest",
+			inlined: "apiVersion: v1\nkind: [Service\n",
+			wantErr: []string{parseErr},
+		},
+		{
+			name:      "Manifest referencing global variables skipped before the variable substitution",
+			inlined:   variableManifest,
+			endpoints: []v1alpha2.Endpoint{generateDummyEndpoint("url1", 8081)},
+		},
+		{
+			name:    "Missing apiVersion and metadata.name",
+			inlined: missingFieldsManifest,
+			wantErr: []string{missingAPIVersionErr, missingNameErr},
+		},
+		{
+			name:      "Endpoint target port not exposed",
+			inlined:   deploymentManifest + "---\n" + serviceManifest,
+			endpoints: []v1alpha2.Endpoint{generateDummyEndpoint("url1", 8081)},
+			wantErr:   []string{unexposedPortErr},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			component := generateDummyKubernetesComponent("component1", tt.endpoints, "")
+			component.Kubernetes.Inlined = tt.inlined
+
+			result := ValidateComponents([]v1alpha2.Component{component})
+
+			if assert.Equal(t, len(tt.wantErr), len(result.Errors), "Error list length should match") {
+				for i := 0; i < len(result.Errors); i++ {
+					assert.Regexp(t, tt.wantErr[i], result.Errors[i].Error(), "Error message should match")
+				}
+			}
+		})
+	}
+}
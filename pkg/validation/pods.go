@@ -14,25 +14,22 @@
 // See the License for the specific language governing permissions and
 // limitations under the License.
 
-package variables
+package validation
 
 import (
 	"github.com/devfile/api/v2/pkg/apis/workspaces/v1alpha2"
 )
 
-// validateAndReplaceForEndpoint validates the endpoint data for global variable references and replaces them with the variable value
-func validateAndReplaceForEndpoint(variables map[string]string, endpoints []v1alpha2.Endpoint) error {
+// mainPod is the pod key of the main devworkspace pod. Component names cannot be empty,
+// so it does not conflict with the pod key of a container component with a dedicated pod
+const mainPod = ""
 
-	invalidKeys := make(map[string]bool)
-
-	for i := range endpoints {
-		var err error
-
-		// Validate endpoint path
-		if endpoints[i].Path, err = validateAndReplaceDataWithVariable(endpoints[i].Path, variables); err != nil {
-			checkForInvalidError(invalidKeys, err)
-		}
+// getPod returns the key of the pod the container component runs in: the main devworkspace pod,
+// shared by all the container components without dedicatedPod, or its own pod, keyed by the component name,
+// if dedicatedPod is set to true
+func getPod(component v1alpha2.Component) string {
+	if component.Container != nil && component.Container.DedicatedPod != nil && *component.Container.DedicatedPod {
+		return component.Name
 	}
-
-	return newInvalidKeysError(invalidKeys)
+	return mainPod
 }
@@ -17,15 +17,39 @@
 package validation
 
 import (
+	"fmt"
+	"net/url"
+	"path"
+	"strings"
+
 	"github.com/devfile/api/v2/pkg/apis/workspaces/v1alpha2"
-	"github.com/hashicorp/go-multierror"
 )
 
+// zipLocationSchemes are the allowed schemes of the zip project source locations
+var zipLocationSchemes = map[string]bool{
+	"file":  true,
+	"http":  true,
+	"https": true,
+}
+
 // ValidateStarterProjects checks if starter project has only one remote configured
-// and if the checkout remote matches the remote configured
-func ValidateStarterProjects(starterProjects []v1alpha2.StarterProject) (returnedErr error) {
+// and if the checkout remote matches the remote configured.
+// It also checks the zip location is a valid file://, http:// or https:// URI
+func ValidateStarterProjects(starterProjects []v1alpha2.StarterProject) (result ValidationResult) {
+	return DefaultRuleRegistry.filter(validateStarterProjects(starterProjects))
+}
+
+// validateStarterProjects runs the built-in checks of the starter projects
+func validateStarterProjects(starterProjects []v1alpha2.StarterProject) (result ValidationResult) {
 
 	for _, starterProject := range starterProjects {
+		if starterProject.Zip != nil {
+			if err := validateZipLocation(starterProject.Zip.Location, "starterProject", starterProject.Name); err != nil {
+				result.addError(newFinding(RuleZipLocation, err, StarterProjectElement, starterProject.Name,
+					fmt.Sprintf("/starterProjects[name=%s]/zip/location", starterProject.Name), starterProject.Attributes))
+			}
+		}
+
 		var gitSource v1alpha2.GitLikeProjectSource
 		if starterProject.Git != nil {
 			gitSource = starterProject.Git.GitLikeProjectSource
@@ -34,52 +58,169 @@ func ValidateStarterProjects(starterProjects []v1alpha2.StarterProject) (returne
 		}
 
 		if starterProjectErr := validateSingleRemoteGitSrc("starterProject", starterProject.Name, gitSource); starterProjectErr != nil {
-			newErr := resolveErrorMessageWithImportAttributes(starterProjectErr, starterProject.Attributes)
-			returnedErr = multierror.Append(returnedErr, newErr)
+			result.addError(newFinding(RuleGitRemote, starterProjectErr, StarterProjectElement, starterProject.Name,
+				fmt.Sprintf("/starterProjects[name=%s]/git", starterProject.Name), starterProject.Attributes))
 		}
 	}
 
-	return returnedErr
+	return result
 }
 
 // ValidateProjects checks if the project has more than one remote configured then a checkout
-// remote is mandatory and if the checkout remote matches the renote configured
-func ValidateProjects(projects []v1alpha2.Project) (returnedErr error) {
+// remote is mandatory and if the checkout remote matches the renote configured.
+// It also checks the project clonePath is a normalized relative path which does not escape the root of the projects,
+// that no two projects clone into the same directory, and that the zip location is a valid file://, http:// or https:// URI
+func ValidateProjects(projects []v1alpha2.Project) (result ValidationResult) {
+	return DefaultRuleRegistry.filter(validateProjects(projects, "projects", ProjectElement))
+}
+
+// ValidateDependentProjects checks the dependent projects with the same rules as ValidateProjects
+func ValidateDependentProjects(dependentProjects []v1alpha2.Project) (result ValidationResult) {
+	return DefaultRuleRegistry.filter(validateProjects(dependentProjects, "dependentProjects", DependentProjectElement))
+}
+
+// ValidateProjectConflicts checks the projects and the dependent projects do not conflict, ie;
+// 1. a project and a dependent project do not have the same name
+// 2. a project and a dependent project do not clone into the same directory, the clonePath defaults to the project name
+//
+// The conflicts within the projects or within the dependent projects are checked by ValidateProjects and ValidateDependentProjects
+func ValidateProjectConflicts(projects []v1alpha2.Project, dependentProjects []v1alpha2.Project) (result ValidationResult) {
+	return DefaultRuleRegistry.filter(validateProjectConflicts(projects, dependentProjects))
+}
+
+// validateProjectConflicts runs the built-in checks of the conflicts between the projects and the dependent projects
+func validateProjectConflicts(projects []v1alpha2.Project, dependentProjects []v1alpha2.Project) (result ValidationResult) {
+	projectNames := make(map[string]bool)
+	projectCloneDirs := make(map[string]string)
+	for _, project := range projects {
+		projectNames[project.Name] = true
+		if _, ok := projectCloneDirs[getCloneDir(project)]; !ok {
+			projectCloneDirs[getCloneDir(project)] = project.Name
+		}
+	}
+
+	for _, dependentProject := range dependentProjects {
+		dependentProjectPath := fmt.Sprintf("/dependentProjects[name=%s]", dependentProject.Name)
+
+		if projectNames[dependentProject.Name] {
+			result.addError(newFinding(RuleProjectNameConflict, &ProjectNameConflictError{name: dependentProject.Name},
+				DependentProjectElement, dependentProject.Name, dependentProjectPath, dependentProject.Attributes))
+		}
+		if projectName, ok := projectCloneDirs[getCloneDir(dependentProject)]; ok {
+			duplicateErr := &DuplicateClonePathError{cloneDir: getCloneDir(dependentProject),
+				projects: []string{fmt.Sprintf("%s %s", ProjectElement, projectName), fmt.Sprintf("%s %s", DependentProjectElement, dependentProject.Name)}}
+			result.addError(newFinding(RuleProjectDuplicateClonePath, duplicateErr,
+				DependentProjectElement, dependentProject.Name, dependentProjectPath+"/clonePath", dependentProject.Attributes))
+		}
+	}
+
+	return result
+}
+
+// getCloneDir returns the normalized directory the project is cloned into, relative to the root of the projects
+func getCloneDir(project v1alpha2.Project) string {
+	if project.ClonePath == "" {
+		return project.Name
+	}
+	return path.Clean(project.ClonePath)
+}
+
+// validateClonePath checks the project clonePath is a normalized relative path which does not escape the root of the projects,
+// it returns the reason the clonePath is invalid or an empty string if it is valid
+func validateClonePath(clonePath string) string {
+	cleanPath := path.Clean(clonePath)
+	switch {
+	case path.IsAbs(clonePath):
+		return "the path must be relative to the root of the projects"
+	case cleanPath == ".." || strings.HasPrefix(cleanPath, "../"):
+		return "the path must not escape the root of the projects"
+	case cleanPath == ".":
+		return "the path must not be the root of the projects"
+	case cleanPath != strings.TrimSuffix(clonePath, "/"):
+		return fmt.Sprintf("the path must be normalized as %s", cleanPath)
+	}
+	return ""
+}
+
+// validateProjects runs the built-in checks of the projects of the given devfile top-level list
+func validateProjects(projects []v1alpha2.Project, listName string, kind ElementKind) (result ValidationResult) {
+	processedCloneDirs := make(map[string]string)
 
 	for _, project := range projects {
+		projectPath := fmt.Sprintf("/%s[name=%s]", listName, project.Name)
+
+		if project.ClonePath != "" {
+			if reason := validateClonePath(project.ClonePath); reason != "" {
+				clonePathErr := &InvalidClonePathError{projectName: project.Name, clonePath: project.ClonePath, reason: reason}
+				result.addError(newFinding(RuleProjectClonePath, clonePathErr, kind, project.Name, projectPath+"/clonePath", project.Attributes))
+			}
+		}
+		cloneDir := getCloneDir(project)
+		if processedProjectName, ok := processedCloneDirs[cloneDir]; ok {
+			duplicateErr := &DuplicateClonePathError{cloneDir: cloneDir,
+				projects: []string{fmt.Sprintf("%s %s", kind, processedProjectName), fmt.Sprintf("%s %s", kind, project.Name)}}
+			result.addError(newFinding(RuleProjectDuplicateClonePath, duplicateErr, kind, project.Name, projectPath+"/clonePath", project.Attributes))
+		} else {
+			processedCloneDirs[cloneDir] = project.Name
+		}
+
+		if project.Zip != nil {
+			if err := validateZipLocation(project.Zip.Location, "project", project.Name); err != nil {
+				result.addError(newFinding(RuleZipLocation, err, kind, project.Name, projectPath+"/zip/location", project.Attributes))
+			}
+		}
+
 		var gitSource v1alpha2.GitLikeProjectSource
 		if project.Git != nil {
 			gitSource = project.Git.GitLikeProjectSource
 		} else {
 			continue
 		}
+		gitPath := fmt.Sprintf("%s/git", projectPath)
 		switch len(gitSource.Remotes) {
 		case 0:
 
-			newErr := resolveErrorMessageWithImportAttributes(&MissingProjectRemoteError{projectName: project.Name}, project.Attributes)
-			returnedErr = multierror.Append(returnedErr, newErr)
+			result.addError(newFinding(RuleProjectRemote, &MissingProjectRemoteError{projectName: project.Name},
+				kind, project.Name, fmt.Sprintf("%s/remotes", gitPath), project.Attributes))
 		case 1:
 			if gitSource.CheckoutFrom != nil && gitSource.CheckoutFrom.Remote != "" {
 				if err := validateRemoteMap(gitSource.Remotes, gitSource.CheckoutFrom.Remote, "project", project.Name); err != nil {
-					newErr := resolveErrorMessageWithImportAttributes(err, project.Attributes)
-					returnedErr = multierror.Append(returnedErr, newErr)
+					result.addError(newFinding(RuleProjectCheckoutRemote, err,
+						kind, project.Name, fmt.Sprintf("%s/checkoutFrom/remote", gitPath), project.Attributes))
 				}
 			}
 		default: // len(gitSource.Remotes) >= 2
 			if gitSource.CheckoutFrom == nil || gitSource.CheckoutFrom.Remote == "" {
 
-				newErr := resolveErrorMessageWithImportAttributes(&MissingProjectCheckoutFromRemoteError{projectName: project.Name}, project.Attributes)
-				returnedErr = multierror.Append(returnedErr, newErr)
+				result.addError(newFinding(RuleProjectMissingCheckout, &MissingProjectCheckoutFromRemoteError{projectName: project.Name},
+					kind, project.Name, fmt.Sprintf("%s/checkoutFrom", gitPath), project.Attributes))
 				continue
 			}
 			if err := validateRemoteMap(gitSource.Remotes, gitSource.CheckoutFrom.Remote, "project", project.Name); err != nil {
-				newErr := resolveErrorMessageWithImportAttributes(err, project.Attributes)
-				returnedErr = multierror.Append(returnedErr, newErr)
+				result.addError(newFinding(RuleProjectCheckoutRemote, err,
+					kind, project.Name, fmt.Sprintf("%s/checkoutFrom/remote", gitPath), project.Attributes))
 			}
 		}
 	}
 
-	return returnedErr
+	return result
+}
+
+// validateZipLocation checks the zip location is a valid URI with one of the allowed schemes: file, http or https
+func validateZipLocation(location, objectType, objectName string) error {
+	if location == "" {
+		return nil
+	}
+	if err := ValidateURI(location); err != nil {
+		return &InvalidZipLocationError{objectType: objectType, objectName: objectName, location: location, reason: err.Error()}
+	}
+
+	parsedLocation, _ := url.Parse(location)
+	if !zipLocationSchemes[strings.ToLower(parsedLocation.Scheme)] {
+		return &InvalidZipLocationError{objectType: objectType, objectName: objectName, location: location,
+			reason: "the location must be a file://, http:// or https:// URI"}
+	}
+	return nil
 }
 
 // validateRemoteMap checks if the checkout remote is present in the project remote map
@@ -122,7 +122,7 @@ func TestValidateStarterProjects(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			err := ValidateStarterProjects(tt.starterProjects)
+			err := ValidateStarterProjects(tt.starterProjects).Err()
 
 			if merr, ok := err.(*multierror.Error); ok && tt.wantErr != nil {
 				assert.Equal(t, len(tt.wantErr), len(merr.Errors), "Error list length should match")
@@ -145,6 +145,17 @@ func TestValidateProjects(t *testing.T) {
 	parentOverridesFromMainDevfile := attributes.Attributes{}.PutString(ImportSourceAttribute,
 		"uri: http://127.0.0.1:8080").PutString(ParentOverrideAttribute, "main devfile")
 	wrongCheckoutErrWithImportAttributes := "unable to find the checkout remote .* in the remotes for project.*, imported from uri: http://127.0.0.1:8080, in parent overrides from main devfile"
+	absoluteClonePathErr := "the clonePath /src/project1 of project project1 is invalid: the path must be relative to the root of the projects"
+	escapingClonePathErr := "the clonePath src/../../project1 of project project1 is invalid: the path must not escape the root of the projects"
+	nonNormalizedClonePathErr := "the clonePath ./src//project1 of project project1 is invalid: the path must be normalized as src/project1"
+	duplicateClonePathErr := "project project1 and project project2 clone into the same directory project1"
+
+	// generateClonePathProject returns a dummy git project cloned into the given path
+	generateClonePathProject := func(name, clonePath string) v1alpha2.Project {
+		project := generateDummyGitProject(name, nil, map[string]string{"origin": "originremote"}, attributes.Attributes{})
+		project.ClonePath = clonePath
+		return project
+	}
 
 	tests := []struct {
 		name     string
@@ -194,6 +205,43 @@ func TestValidateProjects(t *testing.T) {
 			},
 			wantErr: []string{atleastOneRemoteErr, wrongCheckoutErr},
 		},
+		{
+			name: "Valid Project clonePaths",
+			projects: []v1alpha2.Project{
+				generateClonePathProject("project1", "src/project1/"),
+				generateClonePathProject("project2", ""),
+				generateClonePathProject("project3", "project2/nested"),
+			},
+		},
+		{
+			name: "Invalid Project with absolute clonePath",
+			projects: []v1alpha2.Project{
+				generateClonePathProject("project1", "/src/project1"),
+			},
+			wantErr: []string{absoluteClonePathErr},
+		},
+		{
+			name: "Invalid Project with clonePath escaping the root of the projects",
+			projects: []v1alpha2.Project{
+				generateClonePathProject("project1", "src/../../project1"),
+			},
+			wantErr: []string{escapingClonePathErr},
+		},
+		{
+			name: "Invalid Project with non-normalized clonePath",
+			projects: []v1alpha2.Project{
+				generateClonePathProject("project1", "./src//project1"),
+			},
+			wantErr: []string{nonNormalizedClonePathErr},
+		},
+		{
+			name: "Invalid Projects cloned into the defaulted clonePath of another project",
+			projects: []v1alpha2.Project{
+				generateClonePathProject("project1", ""),
+				generateClonePathProject("project2", "project1/"),
+			},
+			wantErr: []string{duplicateClonePathErr},
+		},
 		{
 			name: "Invalid Project due to wrong checkout with import source attributes",
 			projects: []v1alpha2.Project{
@@ -204,7 +252,7 @@ func TestValidateProjects(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			err := ValidateProjects(tt.projects)
+			err := ValidateProjects(tt.projects).Err()
 
 			if merr, ok := err.(*multierror.Error); ok && tt.wantErr != nil {
 				assert.Equal(t, len(tt.wantErr), len(merr.Errors), "Error list length should match")
@@ -217,3 +265,111 @@ func TestValidateProjects(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateProjectConflicts(t *testing.T) {
+
+	// generateClonePathProject returns a dummy git project cloned into the given path
+	generateClonePathProject := func(name, clonePath string) v1alpha2.Project {
+		project := generateDummyGitProject(name, nil, map[string]string{"origin": "originremote"}, attributes.Attributes{})
+		project.ClonePath = clonePath
+		return project
+	}
+
+	nameConflictErr := "project project1 is defined both in projects and dependentProjects"
+	duplicateClonePathErr := "project project1 and dependentProject library clone into the same directory project1"
+
+	tests := []struct {
+		name              string
+		projects          []v1alpha2.Project
+		dependentProjects []v1alpha2.Project
+		wantErr           []string
+	}{
+		{
+			name:              "Valid projects and dependent projects",
+			projects:          []v1alpha2.Project{generateClonePathProject("project1", "")},
+			dependentProjects: []v1alpha2.Project{generateClonePathProject("library", "libs/library")},
+		},
+		{
+			name:              "Project and dependent project with the same name",
+			projects:          []v1alpha2.Project{generateClonePathProject("project1", "")},
+			dependentProjects: []v1alpha2.Project{generateClonePathProject("project1", "libs/project1")},
+			wantErr:           []string{nameConflictErr},
+		},
+		{
+			name:              "Project and dependent project cloned into the same directory",
+			projects:          []v1alpha2.Project{generateClonePathProject("project1", "")},
+			dependentProjects: []v1alpha2.Project{generateClonePathProject("library", "./project1")},
+			wantErr:           []string{duplicateClonePathErr},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := ValidateProjectConflicts(tt.projects, tt.dependentProjects)
+
+			if assert.Equal(t, len(tt.wantErr), len(result.Errors), "Error list length should match") {
+				for i := 0; i < len(result.Errors); i++ {
+					assert.Regexp(t, tt.wantErr[i], result.Errors[i].Error(), "Error message should match")
+				}
+			}
+		})
+	}
+}
+
+func TestValidateZipLocation(t *testing.T) {
+
+	schemeErr := "the zip location .* of project project1 is invalid: the location must be a file://, http:// or https:// URI"
+	invalidURIErr := "the zip location .* of project project1 is invalid: .*invalid"
+
+	tests := []struct {
+		name     string
+		location string
+		wantErr  string
+	}{
+		{
+			name:     "Valid https location",
+			location: "https://github.com/devfile/registry/archive/main.zip",
+		},
+		{
+			name:     "Valid file location",
+			location: "file:///tmp/project.zip",
+		},
+		{
+			name:     "Empty location",
+			location: "",
+		},
+		{
+			name:     "Location without scheme",
+			location: "project.zip",
+			wantErr:  schemeErr,
+		},
+		{
+			name:     "Location with a scheme outside the allowlist",
+			location: "ftp://example.com/project.zip",
+			wantErr:  schemeErr,
+		},
+		{
+			name:     "Invalid http location",
+			location: "http//example.com/project.zip",
+			wantErr:  invalidURIErr,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			project := v1alpha2.Project{
+				Name: "project1",
+				ProjectSource: v1alpha2.ProjectSource{
+					Zip: &v1alpha2.ZipProjectSource{Location: tt.location},
+				},
+			}
+			result := ValidateProjects([]v1alpha2.Project{project})
+
+			if tt.wantErr != "" {
+				if assert.Equal(t, 1, len(result.Errors), "Error list length should match") {
+					assert.Regexp(t, tt.wantErr, result.Errors[0].Error(), "Error message should match")
+				}
+			} else {
+				assert.Equal(t, 0, len(result.Errors), "Error list should be empty")
+			}
+		})
+	}
+}
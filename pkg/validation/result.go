@@ -0,0 +1,116 @@
+//
+//
+// Copyright Red Hat
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validation
+
+import (
+	"github.com/hashicorp/go-multierror"
+)
+
+// Severity is the severity of a validation finding
+type Severity string
+
+const (
+	// ErrorSeverity is used for findings that make the devfile invalid
+	ErrorSeverity Severity = "error"
+	// WarningSeverity is used for findings that do not make the devfile invalid, but are likely a mistake
+	WarningSeverity Severity = "warning"
+	// InfoSeverity is used for informational findings
+	InfoSeverity Severity = "info"
+)
+
+// ValidationResult is the result of a devfile validation, with the findings split by severity
+type ValidationResult struct {
+	// Errors are the findings that make the devfile invalid
+	Errors []*Finding `json:"errors,omitempty"`
+
+	// Warnings are the findings that do not make the devfile invalid, but are likely a mistake
+	Warnings []*Finding `json:"warnings,omitempty"`
+
+	// Info are the informational findings
+	Info []*Finding `json:"info,omitempty"`
+}
+
+// HasErrors returns true if the validation result contains at least one error
+func (r ValidationResult) HasErrors() bool {
+	return len(r.Errors) > 0
+}
+
+// Err returns the errors of the validation result as a multierror, or nil if there is no error
+func (r ValidationResult) Err() error {
+	var returnedErr error
+	for _, finding := range r.Errors {
+		returnedErr = multierror.Append(returnedErr, finding)
+	}
+	return returnedErr
+}
+
+// Merge appends the findings of another validation result to the validation result
+func (r *ValidationResult) Merge(other ValidationResult) {
+	r.Errors = append(r.Errors, other.Errors...)
+	r.Warnings = append(r.Warnings, other.Warnings...)
+	r.Info = append(r.Info, other.Info...)
+}
+
+// PromoteWarnings turns the warnings reported by the given rules into errors, e.g. for a strict CI mode.
+// If no rule id is given, every warning is promoted.
+func (r *ValidationResult) PromoteWarnings(ruleIDs ...RuleID) {
+	promotedRules := make(map[RuleID]bool, len(ruleIDs))
+	for _, ruleID := range ruleIDs {
+		promotedRules[ruleID] = true
+	}
+
+	var warnings []*Finding
+	for _, finding := range r.Warnings {
+		if len(ruleIDs) == 0 || promotedRules[finding.RuleID] {
+			r.addError(finding)
+		} else {
+			warnings = append(warnings, finding)
+		}
+	}
+	r.Warnings = warnings
+}
+
+// add appends the finding to the validation result according to its severity,
+// a finding without severity is appended to the errors
+func (r *ValidationResult) add(finding *Finding) {
+	switch finding.Severity {
+	case WarningSeverity:
+		r.Warnings = append(r.Warnings, finding)
+	case InfoSeverity:
+		r.Info = append(r.Info, finding)
+	default:
+		r.addError(finding)
+	}
+}
+
+// addError appends the finding to the validation result errors
+func (r *ValidationResult) addError(finding *Finding) {
+	finding.Severity = ErrorSeverity
+	r.Errors = append(r.Errors, finding)
+}
+
+// addWarning appends the finding to the validation result warnings
+func (r *ValidationResult) addWarning(finding *Finding) {
+	finding.Severity = WarningSeverity
+	r.Warnings = append(r.Warnings, finding)
+}
+
+// addInfo appends the finding to the validation result informational findings
+func (r *ValidationResult) addInfo(finding *Finding) {
+	finding.Severity = InfoSeverity
+	r.Info = append(r.Info, finding)
+}
@@ -0,0 +1,82 @@
+//
+//
+// Copyright Red Hat
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validation
+
+import (
+	"testing"
+
+	"github.com/devfile/api/v2/pkg/apis/workspaces/v1alpha2"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPromoteWarnings(t *testing.T) {
+
+	component := "alias1"
+
+	components := []v1alpha2.Component{
+		generateDummyContainerComponent(component, nil, nil, nil, v1alpha2.Annotation{}, false),
+	}
+
+	// no default build and run commands
+	commands := []v1alpha2.Command{
+		generateDummyExecCommand("build1", component, &v1alpha2.CommandGroup{Kind: buildGroup}),
+		generateDummyExecCommand("build2", component, &v1alpha2.CommandGroup{Kind: buildGroup}),
+		generateDummyExecCommand("run1", component, &v1alpha2.CommandGroup{Kind: runGroup}),
+		generateDummyExecCommand("run2", component, &v1alpha2.CommandGroup{Kind: runGroup}),
+	}
+
+	tests := []struct {
+		name         string
+		ruleIDs      []RuleID
+		wantErrors   int
+		wantWarnings int
+	}{
+		{
+			name:         "Promote all warnings",
+			wantErrors:   2,
+			wantWarnings: 0,
+		},
+		{
+			name:         "Promote warnings of selected rules",
+			ruleIDs:      []RuleID{RuleGroupMissingDefault},
+			wantErrors:   2,
+			wantWarnings: 0,
+		},
+		{
+			name:         "Promote warnings of a rule without warnings",
+			ruleIDs:      []RuleID{RuleGroupMultipleDefault},
+			wantErrors:   0,
+			wantWarnings: 2,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := ValidateCommands(commands, components)
+			if !assert.False(t, result.HasErrors(), "Validation result should not have errors before promotion") {
+				return
+			}
+
+			result.PromoteWarnings(tt.ruleIDs...)
+
+			assert.Equal(t, tt.wantErrors, len(result.Errors), "Error list length should match")
+			assert.Equal(t, tt.wantWarnings, len(result.Warnings), "Warning list length should match")
+			for _, finding := range result.Errors {
+				assert.Equal(t, ErrorSeverity, finding.Severity, "Promoted finding severity should be error")
+			}
+		})
+	}
+}
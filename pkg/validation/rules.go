@@ -0,0 +1,199 @@
+//
+//
+// Copyright Red Hat
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validation
+
+import (
+	"sync"
+
+	"github.com/devfile/api/v2/pkg/apis/workspaces/v1alpha2"
+)
+
+// RuleScope is the devfile content a validation rule applies to
+type RuleScope string
+
+const (
+	// ComponentsScope rules are run by ValidateComponents
+	ComponentsScope RuleScope = "components"
+	// CommandsScope rules are run by ValidateCommands
+	CommandsScope RuleScope = "commands"
+	// EventsScope rules are run by ValidateEvents
+	EventsScope RuleScope = "events"
+)
+
+// RuleContext is the devfile content a validation rule is run against.
+// Only the content given to the validation function running the rule is set.
+type RuleContext struct {
+	Components []v1alpha2.Component
+	Commands   []v1alpha2.Command
+	Events     *v1alpha2.Events
+}
+
+// Rule is a custom validation rule, e.g. an organization policy, run alongside the built-in checks
+type Rule interface {
+	// ID returns the stable identifier of the rule, used as the RuleID of its findings
+	ID() RuleID
+
+	// Scope returns the devfile content the rule applies to
+	Scope() RuleScope
+
+	// Validate returns the findings of the rule for the given devfile content. Findings without
+	// a severity are reported as errors. Nil findings are skipped, and a finding without Err is
+	// invalid, it is reported as an InvalidRuleFindingError of the rule.
+	Validate(context RuleContext) []*Finding
+}
+
+// ruleFunc is a Rule backed by a function
+type ruleFunc struct {
+	id       RuleID
+	scope    RuleScope
+	validate func(context RuleContext) []*Finding
+}
+
+func (r *ruleFunc) ID() RuleID {
+	return r.id
+}
+
+func (r *ruleFunc) Scope() RuleScope {
+	return r.scope
+}
+
+func (r *ruleFunc) Validate(context RuleContext) []*Finding {
+	return r.validate(context)
+}
+
+// NewRule returns a validation rule with the given id and scope, backed by the validate function
+func NewRule(id RuleID, scope RuleScope, validate func(context RuleContext) []*Finding) Rule {
+	return &ruleFunc{id: id, scope: scope, validate: validate}
+}
+
+// RuleRegistry holds the custom validation rules and the ids of the disabled rules.
+// Both custom and built-in rules can be disabled by id.
+type RuleRegistry struct {
+	mutex    sync.RWMutex
+	rules    []Rule
+	disabled map[RuleID]bool
+}
+
+// DefaultRuleRegistry is the rule registry used by the Validate* functions
+var DefaultRuleRegistry = NewRuleRegistry()
+
+// NewRuleRegistry returns an empty rule registry
+func NewRuleRegistry() *RuleRegistry {
+	return &RuleRegistry{disabled: make(map[RuleID]bool)}
+}
+
+// Register adds a custom rule to the registry, an error is returned if a rule with the same id is already registered
+func (r *RuleRegistry) Register(rule Rule) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	for _, registeredRule := range r.rules {
+		if registeredRule.ID() == rule.ID() {
+			return &DuplicateRuleError{ruleID: rule.ID()}
+		}
+	}
+	r.rules = append(r.rules, rule)
+	return nil
+}
+
+// Unregister removes the custom rule with the given id from the registry
+func (r *RuleRegistry) Unregister(ruleID RuleID) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	for i, rule := range r.rules {
+		if rule.ID() == ruleID {
+			r.rules = append(r.rules[:i], r.rules[i+1:]...)
+			return
+		}
+	}
+}
+
+// Enable enables the rule with the given id
+func (r *RuleRegistry) Enable(ruleID RuleID) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	delete(r.disabled, ruleID)
+}
+
+// Disable disables the rule with the given id, the findings of a disabled rule are not reported
+func (r *RuleRegistry) Disable(ruleID RuleID) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	r.disabled[ruleID] = true
+}
+
+// IsEnabled returns true if the rule with the given id is not disabled
+func (r *RuleRegistry) IsEnabled(ruleID RuleID) bool {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	return !r.disabled[ruleID]
+}
+
+// run returns the findings of the enabled custom rules of the given scope
+func (r *RuleRegistry) run(scope RuleScope, context RuleContext) (result ValidationResult) {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	for _, rule := range r.rules {
+		if rule.Scope() != scope || r.disabled[rule.ID()] {
+			continue
+		}
+		for _, finding := range rule.Validate(context) {
+			// a custom rule is user code, its invalid findings are reported instead of crashing the validation
+			if finding == nil {
+				continue
+			}
+			if finding.Err == nil {
+				result.addError(&Finding{
+					RuleID:     rule.ID(),
+					Path:       finding.Path,
+					Kind:       finding.Kind,
+					Key:        finding.Key,
+					Provenance: finding.Provenance,
+					Err:        &InvalidRuleFindingError{ruleID: rule.ID()},
+				})
+				continue
+			}
+			if finding.RuleID == "" {
+				finding.RuleID = rule.ID()
+			}
+			result.add(finding)
+		}
+	}
+
+	return result
+}
+
+// filter returns the validation result without the findings of the disabled rules
+func (r *RuleRegistry) filter(result ValidationResult) (filteredResult ValidationResult) {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	for _, findings := range [][]*Finding{result.Errors, result.Warnings, result.Info} {
+		for _, finding := range findings {
+			if !r.disabled[finding.RuleID] {
+				filteredResult.add(finding)
+			}
+		}
+	}
+
+	return filteredResult
+}
@@ -0,0 +1,170 @@
+//
+//
+// Copyright Red Hat
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validation
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/devfile/api/v2/pkg/apis/workspaces/v1alpha2"
+	"github.com/stretchr/testify/assert"
+)
+
+// generateNoLatestImageRule returns a custom rule reporting the container components using a latest image
+func generateNoLatestImageRule(severity Severity) Rule {
+	return NewRule("no-latest-image", ComponentsScope, func(context RuleContext) []*Finding {
+		var findings []*Finding
+		for _, component := range context.Components {
+			if component.Container != nil && strings.HasSuffix(component.Container.Image, ":latest") {
+				findings = append(findings, &Finding{
+					Path:     componentTypePath(component) + "/image",
+					Kind:     ComponentElement,
+					Key:      component.Name,
+					Severity: severity,
+					Err:      fmt.Errorf("container component %s uses a latest image", component.Name),
+				})
+			}
+		}
+		return findings
+	})
+}
+
+func TestRuleRegistry(t *testing.T) {
+
+	latestImageComponent := generateDummyContainerComponent("tools", nil, nil, nil, v1alpha2.Annotation{}, false)
+	latestImageComponent.Container.Image = "quay.io/devfile/tools:latest"
+
+	reservedEnvComponent := generateDummyContainerComponent("runtime", nil, nil,
+		[]v1alpha2.EnvVar{{Name: EnvProjectsRoot, Value: "/projects"}}, v1alpha2.Annotation{}, false)
+	reservedEnvComponent.Container.Image = "docker.io/maven:3.8"
+
+	// invalidFindingsRule returns nil findings and a finding without error for each component
+	invalidFindingsRule := NewRule("invalid-findings", ComponentsScope, func(context RuleContext) []*Finding {
+		var findings []*Finding
+		for _, component := range context.Components {
+			findings = append(findings, nil, &Finding{Path: componentTypePath(component), Kind: ComponentElement, Key: component.Name})
+		}
+		return findings
+	})
+
+	latestImageErr := "container component tools uses a latest image"
+	invalidFindingErr := "the validation rule invalid-findings returned a finding without error"
+	reservedEnvErr := ".*env variable PROJECTS_ROOT is reserved and cannot be customized in component runtime"
+
+	tests := []struct {
+		name        string
+		rules       []Rule
+		disabled    []RuleID
+		wantErr     []string
+		wantWarning []string
+	}{
+		{
+			name:    "No custom rule",
+			wantErr: []string{reservedEnvErr},
+		},
+		{
+			name:    "Custom error rule",
+			rules:   []Rule{generateNoLatestImageRule("")},
+			wantErr: []string{reservedEnvErr, latestImageErr},
+		},
+		{
+			name:        "Custom warning rule",
+			rules:       []Rule{generateNoLatestImageRule(WarningSeverity)},
+			wantErr:     []string{reservedEnvErr},
+			wantWarning: []string{latestImageErr},
+		},
+		{
+			name:    "Custom rule returning nil findings and findings without error",
+			rules:   []Rule{invalidFindingsRule},
+			wantErr: []string{reservedEnvErr, invalidFindingErr, invalidFindingErr},
+		},
+		{
+			name:     "Disabled custom rule",
+			rules:    []Rule{generateNoLatestImageRule("")},
+			disabled: []RuleID{"no-latest-image"},
+			wantErr:  []string{reservedEnvErr},
+		},
+		{
+			name:     "Disabled built-in rule",
+			rules:    []Rule{generateNoLatestImageRule("")},
+			disabled: []RuleID{RuleReservedEnv},
+			wantErr:  []string{latestImageErr},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			registry := NewRuleRegistry()
+			for _, rule := range tt.rules {
+				assert.NoError(t, registry.Register(rule), "Rule should be registered")
+			}
+			for _, ruleID := range tt.disabled {
+				registry.Disable(ruleID)
+			}
+
+			spec := &v1alpha2.DevWorkspaceTemplateSpec{
+				DevWorkspaceTemplateSpecContent: v1alpha2.DevWorkspaceTemplateSpecContent{
+					Components: []v1alpha2.Component{reservedEnvComponent, latestImageComponent},
+				},
+			}
+			result := ValidateDevWorkspaceTemplateSpec(spec, ValidationOptions{Rules: registry})
+
+			if assert.Equal(t, len(tt.wantErr), len(result.Errors), "Error list length should match") {
+				for i := 0; i < len(result.Errors); i++ {
+					assert.Regexp(t, tt.wantErr[i], result.Errors[i].Error(), "Error message should match")
+				}
+			}
+			if assert.Equal(t, len(tt.wantWarning), len(result.Warnings), "Warning list length should match") {
+				for i := 0; i < len(result.Warnings); i++ {
+					assert.Regexp(t, tt.wantWarning[i], result.Warnings[i].Error(), "Warning message should match")
+					assert.Equal(t, RuleID("no-latest-image"), result.Warnings[i].RuleID, "Rule id should match")
+				}
+			}
+		})
+	}
+}
+
+func TestRuleRegistryRegister(t *testing.T) {
+	registry := NewRuleRegistry()
+
+	assert.NoError(t, registry.Register(generateNoLatestImageRule("")), "Rule should be registered")
+	assert.Regexp(t, "a validation rule with id no-latest-image is already registered",
+		registry.Register(generateNoLatestImageRule(WarningSeverity)), "Error message should match")
+
+	registry.Disable("no-latest-image")
+	assert.False(t, registry.IsEnabled("no-latest-image"), "Rule should be disabled")
+	registry.Enable("no-latest-image")
+	assert.True(t, registry.IsEnabled("no-latest-image"), "Rule should be enabled")
+
+	registry.Unregister("no-latest-image")
+	assert.NoError(t, registry.Register(generateNoLatestImageRule("")), "Rule should be registered again")
+}
+
+func TestDefaultRuleRegistry(t *testing.T) {
+	latestImageComponent := generateDummyContainerComponent("tools", nil, nil, nil, v1alpha2.Annotation{}, false)
+	latestImageComponent.Container.Image = "quay.io/devfile/tools:latest"
+
+	if !assert.NoError(t, DefaultRuleRegistry.Register(generateNoLatestImageRule(""))) {
+		return
+	}
+	defer DefaultRuleRegistry.Unregister("no-latest-image")
+
+	err := ValidateComponents([]v1alpha2.Component{latestImageComponent}).Err()
+	if assert.Error(t, err) {
+		assert.Regexp(t, "container component tools uses a latest image", err.Error(), "Error message should match")
+	}
+}
@@ -0,0 +1,190 @@
+//
+//
+// Copyright Red Hat
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validation
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/devfile/api/v2/pkg/apis/workspaces/v1alpha2"
+	attributesAPI "github.com/devfile/api/v2/pkg/attributes"
+)
+
+// baseSchemaVersion is the first devfile 2.x schema version
+const baseSchemaVersion = "2.0.0"
+
+// schemaFeature is a devfile construct introduced after the base schema version
+type schemaFeature struct {
+	// name is the human readable name of the construct, e.g. image component
+	name string
+
+	// since is the schema version the construct is introduced in
+	since string
+
+	// find returns the elements of the devworkspace template spec using the construct
+	find func(spec *v1alpha2.DevWorkspaceTemplateSpec) []featureUsage
+}
+
+// featureUsage is a devfile element using a schema feature
+type featureUsage struct {
+	path       string
+	kind       ElementKind
+	key        string
+	attributes attributesAPI.Attributes
+}
+
+// schemaFeatures is the feature matrix of the devfile schema versions, sorted by version
+var schemaFeatures = []schemaFeature{
+	{
+		name:  "variables",
+		since: "2.1.0",
+		find: func(spec *v1alpha2.DevWorkspaceTemplateSpec) (usages []featureUsage) {
+			if len(spec.Variables) > 0 {
+				usages = append(usages, featureUsage{path: "/variables", kind: DevfileElement})
+			}
+			return usages
+		},
+	},
+	{
+		name:  "attributes",
+		since: "2.1.0",
+		find: func(spec *v1alpha2.DevWorkspaceTemplateSpec) (usages []featureUsage) {
+			if len(spec.Attributes) > 0 {
+				usages = append(usages, featureUsage{path: "/attributes", kind: DevfileElement})
+			}
+			return usages
+		},
+	},
+	{
+		name:  "image component",
+		since: "2.2.0",
+		find: func(spec *v1alpha2.DevWorkspaceTemplateSpec) (usages []featureUsage) {
+			for _, component := range spec.Components {
+				if component.Image != nil {
+					usages = append(usages, featureUsage{path: componentTypePath(component), kind: ComponentElement, key: component.Name, attributes: component.Attributes})
+				}
+			}
+			return usages
+		},
+	},
+	{
+		name:  "container annotation",
+		since: "2.2.0",
+		find: func(spec *v1alpha2.DevWorkspaceTemplateSpec) (usages []featureUsage) {
+			for _, component := range spec.Components {
+				if component.Container == nil || component.Container.Annotation == nil {
+					continue
+				}
+				if annotation := component.Container.Annotation; len(annotation.Deployment) > 0 || len(annotation.Service) > 0 {
+					usages = append(usages, featureUsage{path: fmt.Sprintf("%s/annotation", componentTypePath(component)),
+						kind: ComponentElement, key: component.Name, attributes: component.Attributes})
+				}
+			}
+			return usages
+		},
+	},
+	{
+		name:  "deploy command group",
+		since: "2.2.0",
+		find: func(spec *v1alpha2.DevWorkspaceTemplateSpec) (usages []featureUsage) {
+			for _, command := range spec.Commands {
+				if group := GetGroup(command); group != nil && group.Kind == v1alpha2.DeployCommandGroupKind {
+					usages = append(usages, featureUsage{path: fmt.Sprintf("%s/group/kind", commandTypePath(command)),
+						kind: CommandElement, key: command.Id, attributes: command.Attributes})
+				}
+			}
+			return usages
+		},
+	},
+	{
+		name:  "dependentProjects",
+		since: "2.2.0",
+		find: func(spec *v1alpha2.DevWorkspaceTemplateSpec) (usages []featureUsage) {
+			for _, project := range spec.DependentProjects {
+				usages = append(usages, featureUsage{path: fmt.Sprintf("/dependentProjects[name=%s]", project.Name),
+					kind: DependentProjectElement, key: project.Name, attributes: project.Attributes})
+			}
+			return usages
+		},
+	},
+	{
+		name:  "import reference version",
+		since: "2.2.0",
+		find: func(spec *v1alpha2.DevWorkspaceTemplateSpec) (usages []featureUsage) {
+			if spec.Parent != nil && spec.Parent.Version != "" {
+				usages = append(usages, featureUsage{path: "/parent/version", kind: ParentElement})
+			}
+			for _, component := range spec.Components {
+				if component.Plugin != nil && component.Plugin.Version != "" {
+					usages = append(usages, featureUsage{path: fmt.Sprintf("%s/version", componentTypePath(component)),
+						kind: ComponentElement, key: component.Name, attributes: component.Attributes})
+				}
+			}
+			return usages
+		},
+	},
+}
+
+// RequiredSchemaVersion returns the minimum schema version supporting all the constructs used by the devworkspace template spec
+func RequiredSchemaVersion(workspaceTemplateSpec *v1alpha2.DevWorkspaceTemplateSpec) string {
+	requiredVersion := baseSchemaVersion
+	if workspaceTemplateSpec == nil {
+		return requiredVersion
+	}
+	for _, feature := range schemaFeatures {
+		if len(feature.find(workspaceTemplateSpec)) > 0 && compareSchemaVersions(feature.since, requiredVersion) > 0 {
+			requiredVersion = feature.since
+		}
+	}
+	return requiredVersion
+}
+
+// validateSchemaFeatures reports every construct of the devworkspace template spec introduced after the given schema version.
+// The schema version must match schemaVersionRegex
+func validateSchemaFeatures(schemaVersion string, workspaceTemplateSpec *v1alpha2.DevWorkspaceTemplateSpec) (result ValidationResult) {
+	for _, feature := range schemaFeatures {
+		if compareSchemaVersions(schemaVersion, feature.since) >= 0 {
+			continue
+		}
+		for _, usage := range feature.find(workspaceTemplateSpec) {
+			finding := newFinding(RuleSchemaFeature, &UnsupportedSchemaFeatureError{feature: feature.name, since: feature.since, schemaVersion: schemaVersion},
+				usage.kind, usage.key, usage.path, usage.attributes)
+			finding.Suggestion = fmt.Sprintf("set schemaVersion to %s or later", feature.since)
+			result.addError(finding)
+		}
+	}
+	return result
+}
+
+// compareSchemaVersions compares the major, minor and patch versions of two schema versions matching schemaVersionRegex,
+// it returns -1, 0 or 1 if the first version is older, the same or newer than the second one. The pre-release and build
+// metadata are ignored
+func compareSchemaVersions(version, otherVersion string) int {
+	versionMatches := schemaVersionRegex.FindStringSubmatch(version)
+	otherVersionMatches := schemaVersionRegex.FindStringSubmatch(otherVersion)
+	for i := 1; i <= 3; i++ {
+		number, _ := strconv.Atoi(versionMatches[i])
+		otherNumber, _ := strconv.Atoi(otherVersionMatches[i])
+		switch {
+		case number < otherNumber:
+			return -1
+		case number > otherNumber:
+			return 1
+		}
+	}
+	return 0
+}
@@ -0,0 +1,146 @@
+//
+//
+// Copyright Red Hat
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validation
+
+import (
+	"testing"
+
+	"github.com/devfile/api/v2/pkg/apis/workspaces/v1alpha2"
+	"github.com/devfile/api/v2/pkg/attributes"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateSchemaFeatures(t *testing.T) {
+
+	annotatedContainer := generateDummyContainerComponent("runtime", nil, nil, nil, v1alpha2.Annotation{Deployment: map[string]string{"key": "value"}}, false)
+
+	spec := &v1alpha2.DevWorkspaceTemplateSpec{
+		DevWorkspaceTemplateSpecContent: v1alpha2.DevWorkspaceTemplateSpecContent{
+			Variables:  map[string]string{"image": "quay.io/devfile/tools:1.0"},
+			Attributes: attributes.Attributes{}.PutString("key", "value"),
+			Components: []v1alpha2.Component{
+				generateDummyContainerComponent("tools", nil, nil, nil, v1alpha2.Annotation{}, false),
+				annotatedContainer,
+				generateDummyImageComponent("image", v1alpha2.DockerfileSrc{Uri: "Dockerfile"}),
+			},
+			Commands: []v1alpha2.Command{
+				generateDummyApplyCommand("deploy", "image", &v1alpha2.CommandGroup{Kind: v1alpha2.DeployCommandGroupKind}, attributes.Attributes{}),
+			},
+			DependentProjects: []v1alpha2.Project{
+				generateDummyGitProject("backend", nil, map[string]string{"origin": "originremote"}, attributes.Attributes{}),
+			},
+		},
+	}
+	spec.Parent = &v1alpha2.Parent{ImportReference: v1alpha2.ImportReference{
+		ImportReferenceUnion: v1alpha2.ImportReferenceUnion{Id: "nodejs"},
+		Version:              "2.1.0",
+	}}
+
+	variablesErr := "variables requires schemaVersion 2.1.0 or later, but the schemaVersion is 2.0.0"
+	attributesErr := "attributes requires schemaVersion 2.1.0 or later, but the schemaVersion is 2.0.0"
+	imageComponentErr := "image component requires schemaVersion 2.2.0 or later, but the schemaVersion is .*"
+	annotationErr := "container annotation requires schemaVersion 2.2.0 or later, but the schemaVersion is .*"
+	deployGroupErr := "deploy command group requires schemaVersion 2.2.0 or later, but the schemaVersion is .*"
+	dependentProjectsErr := "dependentProjects requires schemaVersion 2.2.0 or later, but the schemaVersion is .*"
+	versionErr := "import reference version requires schemaVersion 2.2.0 or later, but the schemaVersion is .*"
+
+	tests := []struct {
+		name          string
+		schemaVersion string
+		wantErr       []string
+		wantPath      []string
+	}{
+		{
+			name:          "Schema version 2.0.0",
+			schemaVersion: "2.0.0",
+			wantErr:       []string{variablesErr, attributesErr, imageComponentErr, annotationErr, deployGroupErr, dependentProjectsErr, versionErr},
+			wantPath: []string{"/variables", "/attributes", "/components[name=image]/image", "/components[name=runtime]/container/annotation",
+				"/commands[id=deploy]/apply/group/kind", "/dependentProjects[name=backend]", "/parent/version"},
+		},
+		{
+			name:          "Schema version 2.1.0",
+			schemaVersion: "2.1.0",
+			wantErr:       []string{imageComponentErr, annotationErr, deployGroupErr, dependentProjectsErr, versionErr},
+			wantPath: []string{"/components[name=image]/image", "/components[name=runtime]/container/annotation",
+				"/commands[id=deploy]/apply/group/kind", "/dependentProjects[name=backend]", "/parent/version"},
+		},
+		{
+			name:          "Schema version 2.2.0",
+			schemaVersion: "2.2.0",
+		},
+		{
+			name:          "Pre-release schema version",
+			schemaVersion: "2.2.0-alpha",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := validateSchemaFeatures(tt.schemaVersion, spec)
+
+			if assert.Equal(t, len(tt.wantErr), len(result.Errors), "Error list length should match") {
+				for i := 0; i < len(result.Errors); i++ {
+					assert.Regexp(t, tt.wantErr[i], result.Errors[i].Error(), "Error message should match")
+					assert.Equal(t, tt.wantPath[i], result.Errors[i].Path, "Error path should match")
+					assert.Equal(t, RuleSchemaFeature, result.Errors[i].RuleID, "Error rule id should match")
+					assert.Regexp(t, "set schemaVersion to 2.[12].0 or later", result.Errors[i].Suggestion, "Error suggestion should match")
+				}
+			}
+		})
+	}
+
+}
+
+func TestRequiredSchemaVersion(t *testing.T) {
+
+	tests := []struct {
+		name        string
+		spec        *v1alpha2.DevWorkspaceTemplateSpec
+		wantVersion string
+	}{
+		{
+			name:        "Nil spec",
+			wantVersion: "2.0.0",
+		},
+		{
+			name: "Base constructs",
+			spec: &v1alpha2.DevWorkspaceTemplateSpec{DevWorkspaceTemplateSpecContent: v1alpha2.DevWorkspaceTemplateSpecContent{
+				Components: []v1alpha2.Component{generateDummyContainerComponent("tools", nil, nil, nil, v1alpha2.Annotation{}, false)},
+			}},
+			wantVersion: "2.0.0",
+		},
+		{
+			name: "Variables",
+			spec: &v1alpha2.DevWorkspaceTemplateSpec{DevWorkspaceTemplateSpecContent: v1alpha2.DevWorkspaceTemplateSpecContent{
+				Variables: map[string]string{"image": "quay.io/devfile/tools:1.0"},
+			}},
+			wantVersion: "2.1.0",
+		},
+		{
+			name: "Variables and image component",
+			spec: &v1alpha2.DevWorkspaceTemplateSpec{DevWorkspaceTemplateSpecContent: v1alpha2.DevWorkspaceTemplateSpecContent{
+				Variables:  map[string]string{"image": "quay.io/devfile/tools:1.0"},
+				Components: []v1alpha2.Component{generateDummyImageComponent("image", v1alpha2.DockerfileSrc{Uri: "Dockerfile"})},
+			}},
+			wantVersion: "2.2.0",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.wantVersion, RequiredSchemaVersion(tt.spec), "Required schema version should match")
+		})
+	}
+}
@@ -0,0 +1,128 @@
+//
+//
+// Copyright Red Hat
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validation
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+const (
+	// testOperation is the JSON patch operation checking the value at the path is the expected one
+	testOperation = "test"
+
+	// replaceOperation is the JSON patch operation replacing the value at the path
+	replaceOperation = "replace"
+)
+
+// PatchOperation is a JSON patch operation, as defined by RFC 6902, fixing the devfile element a finding refers to
+type PatchOperation struct {
+	// Op is the operation, i.e. test or replace
+	Op string `json:"op"`
+
+	// Path is the JSON pointer to the value of the operation, e.g. /commands/0/exec/component
+	Path string `json:"path"`
+
+	// Value is the value of the operation
+	Value string `json:"value"`
+}
+
+// addKeySuggestion appends to the finding suggestion the closest candidates of the unknown key. If there is a single
+// closest candidate, the finding fix is appended a JSON patch replacing the key at the given JSON pointer. The patch
+// tests the pointer still references the unknown key, so it is not applied to a devfile changed since the validation
+func addKeySuggestion(finding *Finding, key, pointer string, candidates []string) {
+	closestKeys := getClosestKeys(key, candidates)
+	if len(closestKeys) == 0 {
+		return
+	}
+
+	var quotedKeys []string
+	for _, closestKey := range closestKeys {
+		quotedKeys = append(quotedKeys, fmt.Sprintf("%q", closestKey))
+	}
+	suggestion := fmt.Sprintf("did you mean %s instead of %q?", strings.Join(quotedKeys, " or "), key)
+	if finding.Suggestion != "" {
+		suggestion = fmt.Sprintf("%s %s", finding.Suggestion, suggestion)
+	}
+	finding.Suggestion = suggestion
+
+	if len(closestKeys) == 1 && pointer != "" {
+		finding.Fix = append(finding.Fix,
+			PatchOperation{Op: testOperation, Path: pointer, Value: key},
+			PatchOperation{Op: replaceOperation, Path: pointer, Value: closestKeys[0]})
+	}
+}
+
+// getClosestKeys returns the sorted candidates with the smallest case-insensitive edit distance to the key, or nil if no
+// candidate is close enough. A candidate is close enough if at most a third of the key characters are edited, with at least
+// one edit allowed
+func getClosestKeys(key string, candidates []string) []string {
+	maxDistance := (len(key) + 2) / 3
+	closestDistance := maxDistance + 1
+	closestKeys := make(map[string]bool)
+
+	for _, candidate := range candidates {
+		if candidate == key {
+			continue
+		}
+		distance := getEditDistance(strings.ToLower(key), strings.ToLower(candidate))
+		switch {
+		case distance < closestDistance:
+			closestDistance = distance
+			closestKeys = map[string]bool{candidate: true}
+		case distance == closestDistance:
+			closestKeys[candidate] = true
+		}
+	}
+
+	var keys []string
+	for closestKey := range closestKeys {
+		keys = append(keys, closestKey)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// getEditDistance returns the optimal string alignment distance of the two strings: the number of character insertions,
+// deletions, substitutions and transpositions of adjacent characters needed to turn one string into the other
+func getEditDistance(a, b string) int {
+	source, target := []rune(a), []rune(b)
+	distances := make([][]int, len(source)+1)
+	for i := range distances {
+		distances[i] = make([]int, len(target)+1)
+		distances[i][0] = i
+	}
+	for j := range distances[0] {
+		distances[0][j] = j
+	}
+
+	for i := 1; i <= len(source); i++ {
+		for j := 1; j <= len(target); j++ {
+			cost := 1
+			if source[i-1] == target[j-1] {
+				cost = 0
+			}
+			distances[i][j] = min(distances[i-1][j]+1, distances[i][j-1]+1, distances[i-1][j-1]+cost)
+			if i > 1 && j > 1 && source[i-1] == target[j-2] && source[i-2] == target[j-1] {
+				distances[i][j] = min(distances[i][j], distances[i-2][j-2]+1)
+			}
+		}
+	}
+
+	return distances[len(source)][len(target)]
+}
@@ -0,0 +1,165 @@
+//
+//
+// Copyright Red Hat
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validation
+
+import (
+	"testing"
+
+	"github.com/devfile/api/v2/pkg/apis/workspaces/v1alpha2"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetEditDistance(t *testing.T) {
+
+	tests := []struct {
+		a, b         string
+		wantDistance int
+	}{
+		{a: "build", b: "build", wantDistance: 0},
+		{a: "", b: "build", wantDistance: 5},
+		{a: "biuld", b: "build", wantDistance: 1},
+		{a: "buidl", b: "build", wantDistance: 1},
+		{a: "myvol", b: "myvol2", wantDistance: 1},
+		{a: "runtime", b: "rutnime1", wantDistance: 2},
+		{a: "tools", b: "maven", wantDistance: 5},
+	}
+	for _, tt := range tests {
+		t.Run(tt.a+"/"+tt.b, func(t *testing.T) {
+			assert.Equal(t, tt.wantDistance, getEditDistance(tt.a, tt.b), "Edit distance should match")
+		})
+	}
+}
+
+func TestGetClosestKeys(t *testing.T) {
+
+	tests := []struct {
+		name       string
+		key        string
+		candidates []string
+		wantKeys   []string
+	}{
+		{
+			name:       "Single closest key",
+			key:        "biuld",
+			candidates: []string{"run", "build", "debug"},
+			wantKeys:   []string{"build"},
+		},
+		{
+			name:       "Tied closest keys are sorted",
+			key:        "myvol",
+			candidates: []string{"myvol2", "myvol1", "othervol"},
+			wantKeys:   []string{"myvol1", "myvol2"},
+		},
+		{
+			name:       "Case-insensitive distance",
+			key:        "Tools",
+			candidates: []string{"tools", "maven"},
+			wantKeys:   []string{"tools"},
+		},
+		{
+			name:       "No close enough key",
+			key:        "db",
+			candidates: []string{"tools", "maven"},
+		},
+		{
+			name:       "The key itself is not suggested",
+			key:        "tools",
+			candidates: []string{"tools"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.wantKeys, getClosestKeys(tt.key, tt.candidates), "Closest keys should match")
+		})
+	}
+}
+
+func TestKeySuggestions(t *testing.T) {
+
+	components := []v1alpha2.Component{
+		generateDummyContainerComponent("tools", []v1alpha2.VolumeMount{{Name: "cache"}, {Name: "m2repo"}}, nil, nil, v1alpha2.Annotation{}, false),
+		generateDummyVolumeComponent("m2-repo", "1Gi"),
+		generateDummyVolumeComponent("cache1", "1Gi"),
+		generateDummyVolumeComponent("cache2", "1Gi"),
+	}
+	commands := []v1alpha2.Command{
+		generateDummyExecCommand("build", "tols", nil),
+		generateDummyExecCommand("run", "tools", nil),
+		generateDummyCompositeCommand("build-and-run", []string{"build", "rnu"}, nil),
+	}
+
+	t.Run("Exec command component", func(t *testing.T) {
+		result := ValidateCommands(commands, components)
+
+		if assert.Equal(t, 2, len(result.Errors), "Error list length should match") {
+			assert.Equal(t, "did you mean \"tools\" instead of \"tols\"?", result.Errors[0].Suggestion)
+			assert.Equal(t, []PatchOperation{
+				{Op: "test", Path: "/commands/0/exec/component", Value: "tols"},
+				{Op: "replace", Path: "/commands/0/exec/component", Value: "tools"},
+			}, result.Errors[0].Fix)
+
+			// the composite command is invalid because of its build subcommand, the fix applies to the build command
+			assert.Equal(t, "did you mean \"tools\" instead of \"tols\"?", result.Errors[1].Suggestion)
+			assert.Equal(t, "/commands/0/exec/component", result.Errors[1].Fix[1].Path)
+		}
+	})
+
+	t.Run("Composite subcommand", func(t *testing.T) {
+		result := ValidateCommands([]v1alpha2.Command{
+			commands[1],
+			generateDummyCompositeCommand("run-all", []string{"run", "rnu"}, nil),
+		}, components)
+
+		if assert.Equal(t, 1, len(result.Errors), "Error list length should match") {
+			assert.Equal(t, "did you mean \"run\" instead of \"rnu\"?", result.Errors[0].Suggestion)
+			assert.Equal(t, []PatchOperation{
+				{Op: "test", Path: "/commands/1/composite/commands/1", Value: "rnu"},
+				{Op: "replace", Path: "/commands/1/composite/commands/1", Value: "run"},
+			}, result.Errors[0].Fix)
+		}
+	})
+
+	t.Run("Volume mount", func(t *testing.T) {
+		result := ValidateComponents(components)
+
+		if assert.Equal(t, 2, len(result.Errors), "Error list length should match") {
+			// the volume mount is as close to both cache volumes, no fix is suggested
+			assert.Equal(t, "did you mean \"cache1\" or \"cache2\" instead of \"cache\"?", result.Errors[0].Suggestion)
+			assert.Empty(t, result.Errors[0].Fix)
+
+			assert.Equal(t, "did you mean \"m2-repo\" instead of \"m2repo\"?", result.Errors[1].Suggestion)
+			assert.Equal(t, []PatchOperation{
+				{Op: "test", Path: "/components/0/container/volumeMounts/1/name", Value: "m2repo"},
+				{Op: "replace", Path: "/components/0/container/volumeMounts/1/name", Value: "m2-repo"},
+			}, result.Errors[1].Fix)
+		}
+	})
+
+	t.Run("Event command", func(t *testing.T) {
+		result := ValidateEvents(v1alpha2.Events{DevWorkspaceEvents: v1alpha2.DevWorkspaceEvents{
+			PostStart: []string{"run", "biuld", "unknown"},
+		}}, commands)
+
+		if assert.Equal(t, 1, len(result.Errors), "Error list length should match") {
+			assert.Equal(t, "did you mean \"build\" instead of \"biuld\"?", result.Errors[0].Suggestion)
+			assert.Equal(t, []PatchOperation{
+				{Op: "test", Path: "/events/postStart/1", Value: "biuld"},
+				{Op: "replace", Path: "/events/postStart/1", Value: "build"},
+			}, result.Errors[0].Fix)
+		}
+	})
+}
@@ -18,9 +18,11 @@ package validation
 
 import (
 	"net/url"
+	"sort"
 	"strings"
 
 	"github.com/devfile/api/v2/pkg/apis/workspaces/v1alpha2"
+	attributesAPI "github.com/devfile/api/v2/pkg/attributes"
 )
 
 // attribute keys for imported and overridden elements
@@ -46,7 +48,19 @@ func getCommandsMap(commands []v1alpha2.Command) map[string]v1alpha2.Command {
 	return commandMap
 }
 
-// ValidateURI checks if the string is with valid uri format, return error if not valid
+// getSortedKeys returns the keys of the map in sorted order, so the findings are reported in a stable order
+func getSortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// ValidateURI checks if the string is with valid uri format, return error if not valid. It checks a single value
+// without the devfile element it belongs to, the Validate* functions report its error as an invalid-uri finding
+// on the element field, see validateURIField
 func ValidateURI(uri string) error {
 	if strings.HasPrefix(uri, "http") {
 		if _, err := url.ParseRequestURI(uri); err != nil {
@@ -58,3 +72,12 @@ func ValidateURI(uri string) error {
 
 	return nil
 }
+
+// validateURIField checks the uri of the element field with ValidateURI, and reports its error as an invalid-uri finding
+// of the element on the given field path
+func validateURIField(uri string, kind ElementKind, key, path string, attributes attributesAPI.Attributes) (result ValidationResult) {
+	if err := ValidateURI(uri); err != nil {
+		result.addError(newFinding(RuleInvalidURI, err, kind, key, path, attributes))
+	}
+	return result
+}
@@ -18,6 +18,8 @@ package validation
 
 import (
 	"testing"
+
+	"github.com/stretchr/testify/assert"
 )
 
 func TestValidateURI(t *testing.T) {
@@ -52,3 +54,37 @@ func TestValidateURI(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateURIField(t *testing.T) {
+
+	tests := []struct {
+		name    string
+		uri     string
+		wantErr []string
+	}{
+		{
+			name: "Valid URI",
+			uri:  "http://devfile.yaml",
+		},
+		{
+			name:    "Invalid URI",
+			uri:     "http//devfile.yaml",
+			wantErr: []string{"parse \"http//devfile.yaml\": invalid URI for request"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := validateURIField(tt.uri, ComponentElement, "component1", "/components[name=component1]/kubernetes/uri", nil)
+
+			if assert.Equal(t, len(tt.wantErr), len(result.Errors), "Error list length should match") {
+				for i := 0; i < len(result.Errors); i++ {
+					assert.Regexp(t, tt.wantErr[i], result.Errors[i].Error(), "Error message should match")
+					assert.Equal(t, RuleInvalidURI, result.Errors[i].RuleID, "Finding rule should match")
+					assert.Equal(t, ComponentElement, result.Errors[i].Kind, "Finding kind should match")
+					assert.Equal(t, "component1", result.Errors[i].Key, "Finding key should match")
+					assert.Equal(t, "/components[name=component1]/kubernetes/uri", result.Errors[i].Path, "Finding path should match")
+				}
+			}
+		})
+	}
+}
@@ -45,3 +45,39 @@ func newInvalidKeysError(keySet map[string]bool) error {
 
 	return nil
 }
+
+// SelfReferenceError returns an error if a variable value references the variable itself
+type SelfReferenceError struct {
+	Key string
+}
+
+func (e *SelfReferenceError) Error() string {
+	return fmt.Sprintf("variable %s references itself", e.Key)
+}
+
+// ReferenceCycleError returns an error if variable values reference each other in a cycle
+type ReferenceCycleError struct {
+	// Cycle is the chain of the variable keys of the cycle, starting and ending with the same key
+	Cycle []string
+}
+
+func (e *ReferenceCycleError) Error() string {
+	return fmt.Sprintf("variables reference each other in a cycle - %s", strings.Join(e.Cycle, " -> "))
+}
+
+// KeyCollisionError returns an error if the replacement of a map key holding a variable reference is already a key of the map.
+// The key is left unchanged, so the entry of the other key is not overwritten
+type KeyCollisionError struct {
+	// ElementList is the devfile list of the element holding the map, e.g. components
+	ElementList string
+	// Element is the name, or the id, of the element holding the map
+	Element string
+	// Key is the map key holding the variable reference
+	Key string
+	// ReplacedKey is the replacement of the map key
+	ReplacedKey string
+}
+
+func (e *KeyCollisionError) Error() string {
+	return fmt.Sprintf("map key %s is not replaced, its replacement %s is already a key of the map", e.Key, e.ReplacedKey)
+}
@@ -17,14 +17,20 @@
 package variables
 
 import (
+	"reflect"
 	"regexp"
+	"sort"
 	"strings"
 
 	"github.com/devfile/api/v2/pkg/apis/workspaces/v1alpha2"
 )
 
-// example of the regex: {{variable}} / {{ variable }}
-var globalVariableRegex = regexp.MustCompile(`\{\{\s*(.*?)\s*\}\}`)
+// example of the regex: {{variable}} / {{ variable }}. The regex also matches the escaped references, e.g. \{{variable}},
+// which are left unchanged by the substitution and unescaped by UnescapeVariableReferences
+var globalVariableRegex = regexp.MustCompile(`\\?\{\{\s*(.*?)\s*\}\}`)
+
+// escapePrefix is the prefix escaping a variable reference, e.g. \{{.State}} is unescaped to the literal text {{.State}}
+const escapePrefix = `\`
 
 // VariableWarning stores the invalid variable references for each devfile object
 type VariableWarning struct {
@@ -42,46 +48,116 @@ type VariableWarning struct {
 
 	// DependentProjects stores a map of dependent project names to invalid variable references
 	DependentProjects map[string][]string
+
+	// Variables stores a map of variable keys to the invalid variable references in their values
+	Variables map[string][]string
+
+	// Cycles stores the SelfReferenceError and ReferenceCycleError of the variables referencing each other in a cycle
+	Cycles []error
+
+	// KeyCollisions stores the map keys holding a variable reference left unchanged, since their replacement collides with another key of the map
+	KeyCollisions []*KeyCollisionError
+
+	// Sources stores a map of variable keys to the name of the source supplying the variable value
+	Sources map[string]string
+
+	// Undefined stores the sorted keys of the undefined variables referenced in the devfile, across all the devfile objects
+	Undefined []string
 }
 
-// ValidateAndReplaceGlobalVariable validates the workspace template spec data for global variable references and replaces them with the variable value
+// ValidateAndReplaceGlobalVariable validates the workspace template spec data for global variable references and replaces them with the variable value.
+// The VariableWarning is kept as is since pkg/validation depends on this package, validation.ValidateGlobalVariables reports it as findings
 func ValidateAndReplaceGlobalVariable(workspaceTemplateSpec *v1alpha2.DevWorkspaceTemplateSpec) VariableWarning {
+	return ValidateAndReplaceGlobalVariableWithSources(workspaceTemplateSpec, DevfileVariableSources(workspaceTemplateSpec))
+}
+
+// ValidateAndReplaceGlobalVariableWithSources validates the workspace template spec data for global variable references and
+// replaces them with the variable value, where the variable values are taken from the sources in precedence order: a variable
+// defined by multiple sources takes the value of the first one. The workspace template spec variables are left unchanged,
+// so the values of the external sources are not written into the devfile
+func ValidateAndReplaceGlobalVariableWithSources(workspaceTemplateSpec *v1alpha2.DevWorkspaceTemplateSpec, sources []VariableSource) VariableWarning {
 
 	var variableWarning VariableWarning
 
 	if workspaceTemplateSpec != nil {
+		// Merge the variables of the sources, the workspace template spec variables are left unchanged
+		variables, sources := mergeVariableSources(sources)
+		variableWarning.Sources = sources
+
+		// Expand the variables referenced by the variable values
+		variables, variableWarning.Variables, variableWarning.Cycles = resolveVariables(variables)
+
+		var keyCollisions []*KeyCollisionError
+
 		// Validate the components and replace for global variable
-		variableWarning.Components = ValidateAndReplaceForComponents(workspaceTemplateSpec.Variables, workspaceTemplateSpec.Components)
+		variableWarning.Components, keyCollisions = validateAndReplaceForComponents(variables, workspaceTemplateSpec.Components, "components")
+		variableWarning.KeyCollisions = append(variableWarning.KeyCollisions, keyCollisions...)
 
 		// Validate the commands and replace for global variable
-		variableWarning.Commands = ValidateAndReplaceForCommands(workspaceTemplateSpec.Variables, workspaceTemplateSpec.Commands)
+		variableWarning.Commands, keyCollisions = validateAndReplaceForCommands(variables, workspaceTemplateSpec.Commands, "commands")
+		variableWarning.KeyCollisions = append(variableWarning.KeyCollisions, keyCollisions...)
 
 		// Validate the projects and replace for global variable
-		variableWarning.Projects = ValidateAndReplaceForProjects(workspaceTemplateSpec.Variables, workspaceTemplateSpec.Projects)
+		variableWarning.Projects, keyCollisions = validateAndReplaceForProjects(variables, workspaceTemplateSpec.Projects, "projects")
+		variableWarning.KeyCollisions = append(variableWarning.KeyCollisions, keyCollisions...)
 
 		// Validate the starter projects and replace for global variable
-		variableWarning.StarterProjects = ValidateAndReplaceForStarterProjects(workspaceTemplateSpec.Variables, workspaceTemplateSpec.StarterProjects)
+		variableWarning.StarterProjects, keyCollisions = validateAndReplaceForStarterProjects(variables, workspaceTemplateSpec.StarterProjects, "starterProjects")
+		variableWarning.KeyCollisions = append(variableWarning.KeyCollisions, keyCollisions...)
 
-		// Validate the starter projects and replace for global variable
-		variableWarning.DependentProjects = ValidateAndReplaceForProjects(workspaceTemplateSpec.Variables, workspaceTemplateSpec.DependentProjects)
+		// Validate the dependent projects and replace for global variable
+		variableWarning.DependentProjects, keyCollisions = validateAndReplaceForProjects(variables, workspaceTemplateSpec.DependentProjects, "dependentProjects")
+		variableWarning.KeyCollisions = append(variableWarning.KeyCollisions, keyCollisions...)
+
+		variableWarning.Undefined = getUndefinedVariables(variableWarning)
 	}
 
 	return variableWarning
 }
 
+// UnescapeVariableReferences replaces the escaped variable references of the workspace template spec, e.g. \{{.State}},
+// with their literal text {{.State}}. The global variable substitution leaves the escaped references unchanged, the
+// escapes are meant to be removed once, from the final output, after the devfile has been validated and substituted
+func UnescapeVariableReferences(workspaceTemplateSpec *v1alpha2.DevWorkspaceTemplateSpec) {
+	if workspaceTemplateSpec == nil {
+		return
+	}
+
+	for _, value := range []interface{}{
+		workspaceTemplateSpec.Components,
+		workspaceTemplateSpec.Commands,
+		workspaceTemplateSpec.Projects,
+		workspaceTemplateSpec.StarterProjects,
+		workspaceTemplateSpec.DependentProjects,
+	} {
+		replaceFields(reflect.ValueOf(value), unescapeDataWithVariable)
+	}
+}
+
+// unescapeDataWithVariable replaces the escaped variable references of the string with their literal text
+func unescapeDataWithVariable(val string) (string, error) {
+	return globalVariableRegex.ReplaceAllStringFunc(val, func(match string) string {
+		return strings.TrimPrefix(match, escapePrefix)
+	}), nil
+}
+
 // validateAndReplaceDataWithVariable validates the string for a global variable and replaces it. An error
-// is returned if the string references an invalid global variable key
+// is returned if the string references an invalid global variable key. The escaped references, e.g. \{{variable}},
+// are neither validated nor replaced, so the substitution can run again on its own output
 func validateAndReplaceDataWithVariable(val string, variables map[string]string) (string, error) {
-	matches := globalVariableRegex.FindAllStringSubmatch(val, -1)
 	var invalidKeys []string
-	for _, match := range matches {
-		varValue, ok := variables[match[1]]
+	val = globalVariableRegex.ReplaceAllStringFunc(val, func(match string) string {
+		if strings.HasPrefix(match, escapePrefix) {
+			return match
+		}
+		key := globalVariableRegex.FindStringSubmatch(match)[1]
+		varValue, ok := variables[key]
 		if !ok {
-			invalidKeys = append(invalidKeys, match[1])
-		} else {
-			val = strings.Replace(val, match[0], varValue, -1)
+			invalidKeys = append(invalidKeys, key)
+			return match
 		}
-	}
+		return varValue
+	})
 
 	if len(invalidKeys) > 0 {
 		return val, &InvalidKeysError{Keys: invalidKeys}
@@ -89,3 +165,101 @@ func validateAndReplaceDataWithVariable(val string, variables map[string]string)
 
 	return val, nil
 }
+
+// resolveVariables expands the variables referenced by the variable values, in dependency order. It returns the expanded
+// variables, a map of variable keys to the invalid variable references in their values, and the errors of the variables
+// referencing each other in a cycle. The variables of a cycle keep their value unexpanded
+func resolveVariables(variables map[string]string) (map[string]string, map[string][]string, []error) {
+	if len(variables) == 0 {
+		return variables, nil, nil
+	}
+
+	resolver := variableResolver{
+		variables: variables,
+		resolved:  make(map[string]string, len(variables)),
+		visiting:  make(map[string]bool),
+		cyclic:    make(map[string]bool),
+	}
+
+	var keys []string
+	for key := range variables {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		resolver.resolve(key, nil)
+	}
+
+	return resolver.resolved, resolver.invalidKeys, resolver.cycles
+}
+
+// variableResolver expands the variable values through a depth-first traversal of the variable references
+type variableResolver struct {
+	variables map[string]string
+	resolved  map[string]string
+	// visiting are the variables whose references are being expanded
+	visiting map[string]bool
+	// cyclic are the variables of a reference cycle
+	cyclic map[string]bool
+
+	invalidKeys map[string][]string
+	cycles      []error
+}
+
+// resolve expands the value of the variable, where chain is the chain of the variables being expanded to reach it
+func (r *variableResolver) resolve(key string, chain []string) {
+	if _, ok := r.resolved[key]; ok {
+		return
+	}
+
+	if r.visiting[key] {
+		var cycle []string
+		for i := range chain {
+			if chain[i] == key {
+				cycle = append(append(cycle, chain[i:]...), key)
+				break
+			}
+		}
+		for _, cyclicKey := range cycle {
+			r.cyclic[cyclicKey] = true
+		}
+		if len(cycle) == 2 {
+			r.cycles = append(r.cycles, &SelfReferenceError{Key: key})
+		} else {
+			r.cycles = append(r.cycles, &ReferenceCycleError{Cycle: cycle})
+		}
+		return
+	}
+
+	r.visiting[key] = true
+	chain = append(chain, key)
+	value := r.variables[key]
+	for _, match := range globalVariableRegex.FindAllStringSubmatch(value, -1) {
+		if strings.HasPrefix(match[0], escapePrefix) {
+			continue
+		}
+		if _, ok := r.variables[match[1]]; ok {
+			r.resolve(match[1], chain)
+		}
+	}
+	delete(r.visiting, key)
+
+	invalidKeys := make(map[string]bool)
+	if r.cyclic[key] {
+		// the value of a variable of a cycle is not expanded, only its references to undefined variables are checked
+		_, err := validateAndReplaceDataWithVariable(value, r.variables)
+		checkForInvalidError(invalidKeys, err)
+	} else {
+		var err error
+		value, err = validateAndReplaceDataWithVariable(value, r.resolved)
+		checkForInvalidError(invalidKeys, err)
+	}
+	r.resolved[key] = value
+
+	if err := newInvalidKeysError(invalidKeys); err != nil {
+		if r.invalidKeys == nil {
+			r.invalidKeys = make(map[string][]string)
+		}
+		r.invalidKeys[key] = err.(*InvalidKeysError).Keys
+	}
+}
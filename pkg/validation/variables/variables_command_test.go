@@ -65,9 +65,9 @@ func TestValidateAndReplaceExecCommand(t *testing.T) {
 
 			var err error
 			if reflect.DeepEqual(testExecCommand, v1alpha2.ExecCommand{}) {
-				err = validateAndReplaceForExecCommand(testVariable, nil)
+				err = validateAndReplaceFields(testVariable, nil)
 			} else {
-				err = validateAndReplaceForExecCommand(testVariable, &testExecCommand)
+				err = validateAndReplaceFields(testVariable, &testExecCommand)
 			}
 			_, ok := err.(*InvalidKeysError)
 			if tt.wantErr && !ok {
@@ -124,9 +124,9 @@ func TestValidateAndReplaceCompositeCommand(t *testing.T) {
 
 			var err error
 			if reflect.DeepEqual(testCompositeCommand, v1alpha2.CompositeCommand{}) {
-				err = validateAndReplaceForCompositeCommand(testVariable, nil)
+				err = validateAndReplaceFields(testVariable, nil)
 			} else {
-				err = validateAndReplaceForCompositeCommand(testVariable, &testCompositeCommand)
+				err = validateAndReplaceFields(testVariable, &testCompositeCommand)
 			}
 			_, ok := err.(*InvalidKeysError)
 			if tt.wantErr && !ok {
@@ -183,9 +183,9 @@ func TestValidateAndReplaceApplyCommand(t *testing.T) {
 
 			var err error
 			if reflect.DeepEqual(testApplyCommand, v1alpha2.ApplyCommand{}) {
-				err = validateAndReplaceForApplyCommand(testVariable, nil)
+				err = validateAndReplaceFields(testVariable, nil)
 			} else {
-				err = validateAndReplaceForApplyCommand(testVariable, &testApplyCommand)
+				err = validateAndReplaceFields(testVariable, &testApplyCommand)
 			}
 			_, ok := err.(*InvalidKeysError)
 			if tt.wantErr && !ok {
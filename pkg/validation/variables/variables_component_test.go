@@ -65,9 +65,9 @@ func TestValidateAndReplaceContainerComponent(t *testing.T) {
 
 			var err error
 			if reflect.DeepEqual(testContainerComponent, v1alpha2.ContainerComponent{}) {
-				err = validateAndReplaceForContainerComponent(testVariable, nil)
+				err = validateAndReplaceFields(testVariable, nil)
 			} else {
-				err = validateAndReplaceForContainerComponent(testVariable, &testContainerComponent)
+				err = validateAndReplaceFields(testVariable, &testContainerComponent)
 			}
 
 			_, ok := err.(*InvalidKeysError)
@@ -128,9 +128,9 @@ func TestValidateAndReplaceOpenShiftKubernetesComponent(t *testing.T) {
 
 			var err error
 			if reflect.DeepEqual(testOpenshiftComponent, v1alpha2.OpenshiftComponent{}) {
-				err = validateAndReplaceForOpenShiftComponent(testVariable, nil)
+				err = validateAndReplaceFields(testVariable, nil)
 			} else {
-				err = validateAndReplaceForOpenShiftComponent(testVariable, &testOpenshiftComponent)
+				err = validateAndReplaceFields(testVariable, &testOpenshiftComponent)
 			}
 			if tt.wantErr && err == nil {
 				t.Errorf("Expected error from test but got nil")
@@ -143,9 +143,9 @@ func TestValidateAndReplaceOpenShiftKubernetesComponent(t *testing.T) {
 			}
 
 			if reflect.DeepEqual(testKubernetesComponent, v1alpha2.KubernetesComponent{}) {
-				err = validateAndReplaceForKubernetesComponent(testVariable, nil)
+				err = validateAndReplaceFields(testVariable, nil)
 			} else {
-				err = validateAndReplaceForKubernetesComponent(testVariable, &testKubernetesComponent)
+				err = validateAndReplaceFields(testVariable, &testKubernetesComponent)
 			}
 			_, ok := err.(*InvalidKeysError)
 			if tt.wantErr && !ok {
@@ -237,9 +237,9 @@ func TestValidateAndReplaceImageComponent(t *testing.T) {
 
 			var err error
 			if reflect.DeepEqual(testImageComponent, v1alpha2.ImageComponent{}) {
-				err = validateAndReplaceForImageComponent(testVariable, nil)
+				err = validateAndReplaceFields(testVariable, nil)
 			} else {
-				err = validateAndReplaceForImageComponent(testVariable, &testImageComponent)
+				err = validateAndReplaceFields(testVariable, &testImageComponent)
 			}
 			_, ok := err.(*InvalidKeysError)
 			if tt.wantErr && !ok {
@@ -296,9 +296,9 @@ func TestValidateAndReplaceVolumeComponent(t *testing.T) {
 
 			var err error
 			if reflect.DeepEqual(testVolumeComponent, v1alpha2.VolumeComponent{}) {
-				err = validateAndReplaceForVolumeComponent(testVariable, nil)
+				err = validateAndReplaceFields(testVariable, nil)
 			} else {
-				err = validateAndReplaceForVolumeComponent(testVariable, &testVolumeComponent)
+				err = validateAndReplaceFields(testVariable, &testVolumeComponent)
 			}
 			_, ok := err.(*InvalidKeysError)
 			if tt.wantErr && !ok {
@@ -347,7 +347,7 @@ func TestValidateAndReplaceEnv(t *testing.T) {
 			testVariable := make(map[string]string)
 			readFileToStruct(t, tt.variableFile, &testVariable)
 
-			err := validateAndReplaceForEnv(testVariable, testEnvArr)
+			err := validateAndReplaceFields(testVariable, testEnvArr)
 			_, ok := err.(*InvalidKeysError)
 			if tt.wantErr && !ok {
 				t.Errorf("Expected InvalidKeysError error from test but got %+v", err)
@@ -56,7 +56,7 @@ func TestValidateAndReplaceEndpoint(t *testing.T) {
 			testVariable := make(map[string]string)
 			readFileToStruct(t, tt.variableFile, &testVariable)
 
-			err := validateAndReplaceForEndpoint(testVariable, testEndpointArr)
+			err := validateAndReplaceFields(testVariable, testEndpointArr)
 			_, ok := err.(*InvalidKeysError)
 			if tt.wantErr && !ok {
 				t.Errorf("Expected InvalidKeysError error from test but got %+v", err)
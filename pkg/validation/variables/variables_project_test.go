@@ -160,9 +160,9 @@ func TestValidateAndReplaceProjectSrc(t *testing.T) {
 
 			var err error
 			if reflect.DeepEqual(testProjectSrc, v1alpha2.ProjectSource{}) {
-				err = validateandReplaceForProjectSource(testVariable, nil)
+				err = validateAndReplaceFields(testVariable, nil)
 			} else {
-				err = validateandReplaceForProjectSource(testVariable, &testProjectSrc)
+				err = validateAndReplaceFields(testVariable, &testProjectSrc)
 			}
 			_, ok := err.(*InvalidKeysError)
 			if tt.wantErr && !ok {
@@ -212,9 +212,9 @@ func TestValidateAndReplaceGitProjectSrc(t *testing.T) {
 
 			var err error
 			if reflect.DeepEqual(testProjectGitSrc, v1alpha2.GitLikeProjectSource{}) {
-				err = validateAndReplaceForGitProjectSource(testVariable, nil)
+				err = validateAndReplaceFields(testVariable, nil)
 			} else {
-				err = validateAndReplaceForGitProjectSource(testVariable, &testProjectGitSrc)
+				err = validateAndReplaceFields(testVariable, &testProjectGitSrc)
 			}
 			_, ok := err.(*InvalidKeysError)
 			if tt.wantErr && !ok {
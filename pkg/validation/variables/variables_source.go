@@ -0,0 +1,102 @@
+//
+//
+// Copyright Red Hat
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package variables
+
+import (
+	"sort"
+
+	"github.com/devfile/api/v2/pkg/apis/workspaces/v1alpha2"
+)
+
+const (
+	// MainDevfileSource is the name of the source of the variables of the main devfile
+	MainDevfileSource = "main devfile"
+
+	// ParentDevfileSource is the name of the source of the parent variables, e.g. the parent variables overridden in the devfile
+	ParentDevfileSource = "parent devfile"
+)
+
+// VariableSource is a named set of variable values, e.g. the values of a CLI flag, of the environment or of a values file
+type VariableSource struct {
+	// Name describes the source in the variable report, e.g. the name of the CLI flag or the path of the values file
+	Name string
+
+	// Variables are the variable values supplied by the source
+	Variables map[string]string
+}
+
+// DevfileVariableSources returns the variable sources of the workspace template spec, in precedence order: the variables of
+// the main devfile, then the parent variables overridden in the devfile. The external sources, e.g. a CLI flag, the environment or
+// a values file, take precedence over the devfile variables and are expected to be prepended to the returned sources
+func DevfileVariableSources(workspaceTemplateSpec *v1alpha2.DevWorkspaceTemplateSpec) []VariableSource {
+	if workspaceTemplateSpec == nil {
+		return nil
+	}
+
+	sources := []VariableSource{{Name: MainDevfileSource, Variables: workspaceTemplateSpec.Variables}}
+	if parent := workspaceTemplateSpec.Parent; parent != nil && len(parent.Variables) > 0 {
+		sources = append(sources, VariableSource{Name: ParentDevfileSource, Variables: parent.Variables})
+	}
+	return sources
+}
+
+// mergeVariableSources merges the variables of the sources in precedence order. It returns the merged variables, and a map
+// of variable keys to the name of the source supplying the variable value. The merged variables are nil if no source
+// defines a variable
+func mergeVariableSources(sources []VariableSource) (map[string]string, map[string]string) {
+	var variables, variableSources map[string]string
+	for _, source := range sources {
+		for key, value := range source.Variables {
+			if _, ok := variables[key]; ok {
+				continue
+			}
+			if variables == nil {
+				variables = make(map[string]string)
+				variableSources = make(map[string]string)
+			}
+			variables[key] = value
+			variableSources[key] = source.Name
+		}
+	}
+	return variables, variableSources
+}
+
+// getUndefinedVariables returns the sorted keys of the undefined variables referenced by the devfile objects
+func getUndefinedVariables(variableWarning VariableWarning) []string {
+	undefinedKeys := make(map[string]bool)
+	for _, invalidKeys := range []map[string][]string{
+		variableWarning.Commands,
+		variableWarning.Components,
+		variableWarning.Projects,
+		variableWarning.StarterProjects,
+		variableWarning.DependentProjects,
+		variableWarning.Variables,
+	} {
+		for _, keys := range invalidKeys {
+			for _, key := range keys {
+				undefinedKeys[key] = true
+			}
+		}
+	}
+
+	var undefined []string
+	for key := range undefinedKeys {
+		undefined = append(undefined, key)
+	}
+	sort.Strings(undefined)
+	return undefined
+}
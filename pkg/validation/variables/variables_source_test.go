@@ -0,0 +1,121 @@
+//
+//
+// Copyright Red Hat
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package variables
+
+import (
+	"testing"
+
+	"github.com/devfile/api/v2/pkg/apis/workspaces/v1alpha2"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateAndReplaceGlobalVariableWithSources(t *testing.T) {
+
+	flagSource := VariableSource{Name: "--var", Variables: map[string]string{"tag": "flag"}}
+	envSource := VariableSource{Name: "environment", Variables: map[string]string{"tag": "env", "registry": "quay.io/env"}}
+
+	tests := []struct {
+		name            string
+		variables       map[string]string
+		parentVariables map[string]string
+		externalSources []VariableSource
+		wantImage       string
+		wantCommandLine string
+		wantSources     map[string]string
+		wantUndefined   []string
+	}{
+		{
+			name:            "Devfile variables",
+			variables:       map[string]string{"registry": "quay.io/devfile", "tag": "main", "script": "run.sh"},
+			wantImage:       "quay.io/devfile/app:main",
+			wantCommandLine: "run.sh --tag main",
+			wantSources:     map[string]string{"registry": MainDevfileSource, "tag": MainDevfileSource, "script": MainDevfileSource},
+		},
+		{
+			name:            "Main devfile variables take precedence over the parent variables",
+			variables:       map[string]string{"tag": "main"},
+			parentVariables: map[string]string{"tag": "parent", "registry": "quay.io/parent", "script": "run.sh"},
+			wantImage:       "quay.io/parent/app:main",
+			wantCommandLine: "run.sh --tag main",
+			wantSources:     map[string]string{"registry": ParentDevfileSource, "tag": MainDevfileSource, "script": ParentDevfileSource},
+		},
+		{
+			name:            "External sources take precedence in order",
+			variables:       map[string]string{"tag": "main", "registry": "quay.io/devfile"},
+			parentVariables: map[string]string{"tag": "parent", "script": "run.sh"},
+			externalSources: []VariableSource{flagSource, envSource},
+			wantImage:       "quay.io/env/app:flag",
+			wantCommandLine: "run.sh --tag flag",
+			wantSources:     map[string]string{"registry": "environment", "tag": "--var", "script": ParentDevfileSource},
+		},
+		{
+			name:            "Undefined variables",
+			variables:       map[string]string{"registry": "{{host}}/devfile"},
+			wantImage:       "{{host}}/devfile/app:{{tag}}",
+			wantCommandLine: "{{script}} --tag {{tag}}",
+			wantSources:     map[string]string{"registry": MainDevfileSource},
+			wantUndefined:   []string{"host", "script", "tag"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			spec := &v1alpha2.DevWorkspaceTemplateSpec{
+				DevWorkspaceTemplateSpecContent: v1alpha2.DevWorkspaceTemplateSpecContent{
+					Variables: tt.variables,
+					Components: []v1alpha2.Component{
+						{
+							Name: "runtime",
+							ComponentUnion: v1alpha2.ComponentUnion{
+								Container: &v1alpha2.ContainerComponent{Container: v1alpha2.Container{Image: "{{registry}}/app:{{tag}}"}},
+							},
+						},
+					},
+					Commands: []v1alpha2.Command{
+						{
+							Id: "run",
+							CommandUnion: v1alpha2.CommandUnion{
+								Exec: &v1alpha2.ExecCommand{CommandLine: "{{script}} --tag {{tag}}", Component: "runtime"},
+							},
+						},
+					},
+				},
+			}
+			if tt.parentVariables != nil {
+				spec.Parent = &v1alpha2.Parent{ParentOverrides: v1alpha2.ParentOverrides{Variables: tt.parentVariables}}
+			}
+
+			originalVariables := make(map[string]string)
+			for key, value := range tt.variables {
+				originalVariables[key] = value
+			}
+
+			sources := append(tt.externalSources, DevfileVariableSources(spec)...)
+			warning := ValidateAndReplaceGlobalVariableWithSources(spec, sources)
+
+			if tt.variables != nil {
+				assert.Equal(t, originalVariables, spec.Variables, "The devfile variables should be left unchanged")
+			} else {
+				assert.Nil(t, spec.Variables, "The external variables should not be written into the devfile")
+			}
+
+			assert.Equal(t, tt.wantImage, spec.Components[0].Container.Image, "The image should be the same")
+			assert.Equal(t, tt.wantCommandLine, spec.Commands[0].Exec.CommandLine, "The command line should be the same")
+			assert.Equal(t, tt.wantSources, warning.Sources, "The variable sources should be the same")
+			assert.Equal(t, tt.wantUndefined, warning.Undefined, "The undefined variables should be the same")
+		})
+	}
+}
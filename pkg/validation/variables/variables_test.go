@@ -157,6 +157,23 @@ func TestValidateAndReplaceDataWithVariable(t *testing.T) {
 			wantValue: "image-1.x.x:dev{{invalid}}-14{{invald}}",
 			wantErr:   &invalidVariableErr,
 		},
+		{
+			name:       "Escaped variable reference",
+			testString: `docker inspect --format '\{{.State.Status}}' {{name}}-\{{ tag }}`,
+			variables: map[string]string{
+				"name": "runtime",
+				"tag":  "dev",
+			},
+			wantValue: `docker inspect --format '\{{.State.Status}}' runtime-\{{ tag }}`,
+			wantErr:   nil,
+		},
+		{
+			name:       "Invalid variable reference along with an escaped reference",
+			testString: `{{image}}:\{{tag}}`,
+			variables:  map[string]string{},
+			wantValue:  `{{image}}:\{{tag}}`,
+			wantErr:    &invalidVariableErr,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -173,6 +190,135 @@ func TestValidateAndReplaceDataWithVariable(t *testing.T) {
 	}
 }
 
+func TestValidateGlobalVariableIdempotent(t *testing.T) {
+	testDWT := v1alpha2.DevWorkspaceTemplateSpec{}
+	readFileToStruct(t, "test-fixtures/all/devfile-good.yaml", &testDWT)
+
+	warning := ValidateAndReplaceGlobalVariable(&testDWT)
+	assert.Empty(t, warning.Undefined, "The first substitution should not report undefined variables")
+
+	// the substitution runs again on its own output, e.g. when a parsed devfile is validated, and leaves the escaped references unchanged
+	warning = ValidateAndReplaceGlobalVariable(&testDWT)
+	assert.Empty(t, warning.Undefined, "The second substitution should not report undefined variables")
+
+	expectedDWT := v1alpha2.DevWorkspaceTemplateSpec{}
+	readFileToStruct(t, "test-fixtures/all/devfile-good-output.yaml", &expectedDWT)
+	assert.Equal(t, expectedDWT, testDWT, "The two values should be the same.")
+}
+
+func TestUnescapeVariableReferences(t *testing.T) {
+	testDWT := v1alpha2.DevWorkspaceTemplateSpec{}
+	readFileToStruct(t, "test-fixtures/all/devfile-good-output.yaml", &testDWT)
+
+	UnescapeVariableReferences(&testDWT)
+
+	assert.Equal(t, "test-xyz && docker inspect --format {{.State}} test-{{ tag }}", testDWT.Commands[0].Exec.CommandLine,
+		"The escaped variable references should be unescaped")
+	expectedDWT := v1alpha2.DevWorkspaceTemplateSpec{}
+	readFileToStruct(t, "test-fixtures/all/devfile-good-unescaped-output.yaml", &expectedDWT)
+	assert.Equal(t, expectedDWT, testDWT, "The two values should be the same.")
+	assert.Equal(t, "myimage:{{tag}}", testDWT.Variables["image"], "The variables should be left unchanged")
+
+	UnescapeVariableReferences(nil)
+}
+
+func TestResolveVariables(t *testing.T) {
+
+	tests := []struct {
+		name            string
+		variables       map[string]string
+		wantVariables   map[string]string
+		wantInvalidKeys map[string][]string
+		wantErr         []string
+	}{
+		{
+			name: "Variables referencing variables",
+			variables: map[string]string{
+				"registry": "quay.io",
+				"org":      "{{registry}}/devfile",
+				"image":    "{{ org }}/app:{{tag}}",
+				"tag":      "1.0",
+			},
+			wantVariables: map[string]string{
+				"registry": "quay.io",
+				"org":      "quay.io/devfile",
+				"image":    "quay.io/devfile/app:1.0",
+				"tag":      "1.0",
+			},
+		},
+		{
+			name: "Invalid variable references",
+			variables: map[string]string{
+				"org":   "{{registry}}/devfile",
+				"image": "{{org}}/app:{{tag}}",
+			},
+			wantVariables: map[string]string{
+				"org":   "{{registry}}/devfile",
+				"image": "{{registry}}/devfile/app:{{tag}}",
+			},
+			wantInvalidKeys: map[string][]string{
+				"org":   {"registry"},
+				"image": {"tag"},
+			},
+		},
+		{
+			name: "Self reference",
+			variables: map[string]string{
+				"tag":   "{{tag}}-dev",
+				"image": "app:{{tag}}",
+			},
+			wantVariables: map[string]string{
+				"tag":   "{{tag}}-dev",
+				"image": "app:{{tag}}-dev",
+			},
+			wantErr: []string{"variable tag references itself"},
+		},
+		{
+			name: "Reference cycle",
+			variables: map[string]string{
+				"a":     "{{b}}",
+				"b":     "{{c}}{{missing}}",
+				"c":     "{{a}}",
+				"image": "app:{{c}}",
+			},
+			wantVariables: map[string]string{
+				"a":     "{{b}}",
+				"b":     "{{c}}{{missing}}",
+				"c":     "{{a}}",
+				"image": "app:{{a}}",
+			},
+			wantInvalidKeys: map[string][]string{
+				"b": {"missing"},
+			},
+			wantErr: []string{"variables reference each other in a cycle - a -> b -> c -> a"},
+		},
+		{
+			name: "Escaped variable references",
+			variables: map[string]string{
+				"format": `\{{.State}}`,
+				"tag":    `\{{tag}}-{{format}}`,
+			},
+			wantVariables: map[string]string{
+				"format": `\{{.State}}`,
+				"tag":    `\{{tag}}-\{{.State}}`,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotVariables, gotInvalidKeys, gotErrs := resolveVariables(tt.variables)
+			assert.Equal(t, tt.wantVariables, gotVariables, "The variables should be the same")
+			assert.Equal(t, tt.wantInvalidKeys, gotInvalidKeys, "The invalid keys should be the same")
+
+			var gotErr []string
+			for _, err := range gotErrs {
+				gotErr = append(gotErr, err.Error())
+			}
+			assert.Equal(t, tt.wantErr, gotErr, "The errors should be the same")
+		})
+	}
+}
+
 func readFileToStruct(t *testing.T, path string, into interface{}) {
 	bytes, err := ioutil.ReadFile(path)
 	if err != nil {
@@ -0,0 +1,205 @@
+//
+//
+// Copyright Red Hat
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package variables
+
+import (
+	"encoding/json"
+	"reflect"
+	"sort"
+
+	attributesAPI "github.com/devfile/api/v2/pkg/attributes"
+	apiext "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+)
+
+// variablesTag is the struct tag key declaring a field exempt from the global variable replacement, with the value "-".
+// The element identifiers, e.g. command id or component name, the references to identifiers, e.g. a command's component,
+// the string enums, e.g. command group kind, and the union discriminators are exempt:
+//
+//	Id string `json:"id" variables:"-"`
+const variablesTag = "variables"
+
+var attributesType = reflect.TypeOf(attributesAPI.Attributes{})
+
+// validateAndReplaceFields validates every string field of the value for global variable references and replaces them
+// with the variable value. The value is walked through pointers, structs, slices and maps, the string map keys are replaced
+// along with the values, and the attribute values are replaced in their JSON content. The struct fields tagged with
+// `variables:"-"` are skipped. Returns an InvalidKeysError if the value references invalid global variable keys
+func validateAndReplaceFields(variables map[string]string, value interface{}) error {
+	_, err := validateAndReplaceFieldsWithKeyCollisions(variables, value)
+	return err
+}
+
+// validateAndReplaceFieldsWithKeyCollisions validates and replaces the string fields of the value as validateAndReplaceFields,
+// it also returns the map keys left unchanged since their replacement collides with another key
+func validateAndReplaceFieldsWithKeyCollisions(variables map[string]string, value interface{}) ([]*KeyCollisionError, error) {
+	if value == nil {
+		return nil, nil
+	}
+
+	invalidKeys := make(map[string]bool)
+	keyCollisions := replaceFields(reflect.ValueOf(value), func(val string) (string, error) {
+		replaced, err := validateAndReplaceDataWithVariable(val, variables)
+		checkForInvalidError(invalidKeys, err)
+		return replaced, err
+	})
+
+	return keyCollisions, newInvalidKeysError(invalidKeys)
+}
+
+// replaceFunc returns the replacement of a string field. A map key is left unchanged if an error is returned
+type replaceFunc func(val string) (string, error)
+
+// replaceFields walks the value and replaces its string fields, the values that cannot be set are not replaced.
+// Returns the map keys left unchanged since their replacement collides with another key
+func replaceFields(value reflect.Value, replace replaceFunc) (keyCollisions []*KeyCollisionError) {
+	switch value.Kind() {
+	case reflect.Ptr:
+		if !value.IsNil() {
+			keyCollisions = replaceFields(value.Elem(), replace)
+		}
+	case reflect.Struct:
+		for i := 0; i < value.NumField(); i++ {
+			field := value.Type().Field(i)
+			if field.PkgPath != "" || field.Tag.Get(variablesTag) == "-" {
+				continue
+			}
+			keyCollisions = append(keyCollisions, replaceFields(value.Field(i), replace)...)
+		}
+	case reflect.Slice:
+		// byte slices, e.g. raw embedded resources, are not strings to replace
+		if value.Type().Elem().Kind() == reflect.Uint8 {
+			return nil
+		}
+		for i := 0; i < value.Len(); i++ {
+			keyCollisions = append(keyCollisions, replaceFields(value.Index(i), replace)...)
+		}
+	case reflect.Map:
+		if value.Type() == attributesType {
+			replaceAttributes(value.Interface().(attributesAPI.Attributes), replace)
+		} else {
+			keyCollisions = replaceMap(value, replace)
+		}
+	case reflect.String:
+		if !value.CanSet() {
+			return nil
+		}
+		replaced, _ := replace(value.String())
+		value.SetString(replaced)
+	}
+	return keyCollisions
+}
+
+// replaceMap walks the map values and replaces their string fields, along with the string map keys. A key whose replacement
+// is already a key of the map, or the replacement of another key, is left unchanged so no map entry is overwritten, and
+// is returned as a key collision
+func replaceMap(value reflect.Value, replace replaceFunc) (keyCollisions []*KeyCollisionError) {
+	keys := value.MapKeys()
+	// replacedKeys are the replacements of the string keys holding a variable reference, targetKeys count
+	// the keys of the map once replaced
+	replacedKeys := make(map[string]string)
+	targetKeys := make(map[string]int)
+	for _, key := range keys {
+		mapValue := reflect.New(value.Type().Elem()).Elem()
+		mapValue.Set(value.MapIndex(key))
+		keyCollisions = append(keyCollisions, replaceFields(mapValue, replace)...)
+		// map values are not addressable, the value is replaced in a copy set back in the map
+		value.SetMapIndex(key, mapValue)
+
+		if key.Kind() != reflect.String {
+			continue
+		}
+		targetKey := key.String()
+		if replaced, err := replace(key.String()); err == nil && replaced != key.String() {
+			replacedKeys[key.String()] = replaced
+			targetKey = replaced
+		}
+		targetKeys[targetKey]++
+	}
+
+	// the replaced keys are removed before being set back, so a replacement matching another replaced key does not overwrite it
+	var collidingKeys []string
+	var updatedKeys, updatedValues []reflect.Value
+	for _, key := range keys {
+		replaced, ok := replacedKeys[key.String()]
+		if !ok {
+			continue
+		}
+		if targetKeys[replaced] > 1 {
+			collidingKeys = append(collidingKeys, key.String())
+			continue
+		}
+		updatedKey := reflect.New(key.Type()).Elem()
+		updatedKey.SetString(replaced)
+		updatedKeys = append(updatedKeys, updatedKey)
+		updatedValues = append(updatedValues, value.MapIndex(key))
+		value.SetMapIndex(key, reflect.Value{})
+	}
+	for i := range updatedKeys {
+		value.SetMapIndex(updatedKeys[i], updatedValues[i])
+	}
+
+	sort.Strings(collidingKeys)
+	for _, key := range collidingKeys {
+		keyCollisions = append(keyCollisions, &KeyCollisionError{Key: key, ReplacedKey: replacedKeys[key]})
+	}
+	return keyCollisions
+}
+
+// replaceAttributes replaces the string values of the attributes JSON content, the attribute keys are not replaced
+func replaceAttributes(attributes attributesAPI.Attributes, replace replaceFunc) {
+	for key, attribute := range attributes {
+		var content interface{}
+		if err := json.Unmarshal(attribute.Raw, &content); err != nil {
+			continue
+		}
+		content, replaced := replaceJSON(content, replace)
+		if !replaced {
+			continue
+		}
+		if raw, err := json.Marshal(content); err == nil {
+			attributes[key] = apiext.JSON{Raw: raw}
+		}
+	}
+}
+
+// replaceJSON replaces the strings of the decoded JSON content. It returns the content with the strings replaced,
+// and whether a string has been replaced
+func replaceJSON(content interface{}, replace replaceFunc) (interface{}, bool) {
+	replaced := false
+	switch typedContent := content.(type) {
+	case string:
+		value, _ := replace(typedContent)
+		return value, value != typedContent
+	case map[string]interface{}:
+		for key, item := range typedContent {
+			item, itemReplaced := replaceJSON(item, replace)
+			if itemReplaced {
+				typedContent[key] = item
+				replaced = true
+			}
+		}
+	case []interface{}:
+		for i, item := range typedContent {
+			item, itemReplaced := replaceJSON(item, replace)
+			if itemReplaced {
+				typedContent[i] = item
+				replaced = true
+			}
+		}
+	}
+	return content, replaced
+}
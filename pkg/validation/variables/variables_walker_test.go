@@ -0,0 +1,284 @@
+//
+//
+// Copyright Red Hat
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package variables
+
+import (
+	"testing"
+
+	"github.com/devfile/api/v2/pkg/apis/workspaces/v1alpha2"
+	"github.com/devfile/api/v2/pkg/attributes"
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func TestValidateAndReplaceFields(t *testing.T) {
+
+	variables := map[string]string{
+		"name":     "runtime",
+		"cpu":      "500m",
+		"host":     "example.com",
+		"registry": "https://registry.devfile.io",
+		"kind":     "build",
+		"tag":      "dev",
+	}
+
+	tests := []struct {
+		name        string
+		value       interface{}
+		wantValue   interface{}
+		wantInvalid []string
+	}{
+		{
+			name: "Container component fields",
+			value: &v1alpha2.Component{
+				Name:       "{{name}}",
+				Attributes: attributes.Attributes{}.PutString("app/host", "{{host}}").PutInteger("replicas", 2),
+				ComponentUnion: v1alpha2.ComponentUnion{
+					Container: &v1alpha2.ContainerComponent{
+						Container: v1alpha2.Container{
+							Image:        "quay.io/devfile/{{name}}:{{tag}}",
+							CpuLimit:     "{{cpu}}",
+							Annotation:   &v1alpha2.Annotation{Deployment: map[string]string{"{{name}}/tag": "{{tag}}"}},
+							VolumeMounts: []v1alpha2.VolumeMount{{Name: "{{name}}", Path: "/{{name}}"}},
+						},
+						Endpoints: []v1alpha2.Endpoint{
+							{
+								Name:        "{{name}}",
+								TargetPort:  8080,
+								Exposure:    "{{exposure}}",
+								Annotations: map[string]string{"host": "{{host}}"},
+								Attributes:  attributes.Attributes{}.Put("labels", map[string]string{"tag": "{{tag}}"}, nil),
+							},
+						},
+					},
+				},
+			},
+			wantValue: &v1alpha2.Component{
+				Name:       "{{name}}",
+				Attributes: attributes.Attributes{}.PutString("app/host", "example.com").PutInteger("replicas", 2),
+				ComponentUnion: v1alpha2.ComponentUnion{
+					Container: &v1alpha2.ContainerComponent{
+						Container: v1alpha2.Container{
+							Image:        "quay.io/devfile/runtime:dev",
+							CpuLimit:     "500m",
+							Annotation:   &v1alpha2.Annotation{Deployment: map[string]string{"runtime/tag": "dev"}},
+							VolumeMounts: []v1alpha2.VolumeMount{{Name: "{{name}}", Path: "/runtime"}},
+						},
+						Endpoints: []v1alpha2.Endpoint{
+							{
+								Name:        "{{name}}",
+								TargetPort:  8080,
+								Exposure:    "{{exposure}}",
+								Annotations: map[string]string{"host": "example.com"},
+								Attributes:  attributes.Attributes{}.Put("labels", map[string]string{"tag": "dev"}, nil),
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "Dockerfile registry source",
+			value: &v1alpha2.DockerfileImage{
+				DockerfileSrc: v1alpha2.DockerfileSrc{
+					SrcType:         "{{kind}}",
+					DevfileRegistry: &v1alpha2.DockerfileDevfileRegistrySource{Id: "nodejs", RegistryUrl: "{{registry}}"},
+				},
+				Dockerfile: v1alpha2.Dockerfile{Args: []string{"TAG={{tag}}", "{{missing}}"}},
+			},
+			wantValue: &v1alpha2.DockerfileImage{
+				DockerfileSrc: v1alpha2.DockerfileSrc{
+					SrcType:         "{{kind}}",
+					DevfileRegistry: &v1alpha2.DockerfileDevfileRegistrySource{Id: "nodejs", RegistryUrl: "https://registry.devfile.io"},
+				},
+				Dockerfile: v1alpha2.Dockerfile{Args: []string{"TAG=dev", "{{missing}}"}},
+			},
+			wantInvalid: []string{"missing"},
+		},
+		{
+			name: "Exempt command fields",
+			value: &v1alpha2.Command{
+				Id: "{{name}}",
+				CommandUnion: v1alpha2.CommandUnion{
+					Exec: &v1alpha2.ExecCommand{
+						LabeledCommand: v1alpha2.LabeledCommand{
+							BaseCommand: v1alpha2.BaseCommand{Group: &v1alpha2.CommandGroup{Kind: "{{kind}}"}},
+							Label:       "Build {{tag}}",
+						},
+						CommandLine: "mvn package -P{{tag}}",
+						Component:   "{{name}}",
+					},
+				},
+			},
+			wantValue: &v1alpha2.Command{
+				Id: "{{name}}",
+				CommandUnion: v1alpha2.CommandUnion{
+					Exec: &v1alpha2.ExecCommand{
+						LabeledCommand: v1alpha2.LabeledCommand{
+							BaseCommand: v1alpha2.BaseCommand{Group: &v1alpha2.CommandGroup{Kind: "{{kind}}"}},
+							Label:       "Build dev",
+						},
+						CommandLine: "mvn package -Pdev",
+						Component:   "{{name}}",
+					},
+				},
+			},
+		},
+		{
+			name: "Plugin component overrides, exempt plugin import reference and raw embedded resources",
+			value: []v1alpha2.Component{
+				{
+					Name: "plugin",
+					ComponentUnion: v1alpha2.ComponentUnion{
+						Plugin: &v1alpha2.PluginComponent{
+							ImportReference: v1alpha2.ImportReference{
+								ImportReferenceUnion: v1alpha2.ImportReferenceUnion{ImportReferenceType: "{{kind}}", Id: "{{name}}"},
+								RegistryUrl:          "{{registry}}",
+							},
+							PluginOverrides: v1alpha2.PluginOverrides{
+								Components: []v1alpha2.ComponentPluginOverride{
+									{
+										Name: "{{name}}",
+										ComponentUnionPluginOverride: v1alpha2.ComponentUnionPluginOverride{
+											Container: &v1alpha2.ContainerComponentPluginOverride{
+												ContainerPluginOverride: v1alpha2.ContainerPluginOverride{Image: "quay.io/devfile/{{name}}:{{tag}}"},
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+				{
+					Name: "custom",
+					ComponentUnion: v1alpha2.ComponentUnion{
+						Custom: &v1alpha2.CustomComponent{
+							ComponentClass:   "{{name}}",
+							EmbeddedResource: runtime.RawExtension{Raw: []byte(`{"tag":"{{tag}}"}`)},
+						},
+					},
+				},
+			},
+			wantValue: []v1alpha2.Component{
+				{
+					Name: "plugin",
+					ComponentUnion: v1alpha2.ComponentUnion{
+						Plugin: &v1alpha2.PluginComponent{
+							ImportReference: v1alpha2.ImportReference{
+								ImportReferenceUnion: v1alpha2.ImportReferenceUnion{ImportReferenceType: "{{kind}}", Id: "{{name}}"},
+								RegistryUrl:          "{{registry}}",
+							},
+							PluginOverrides: v1alpha2.PluginOverrides{
+								Components: []v1alpha2.ComponentPluginOverride{
+									{
+										Name: "{{name}}",
+										ComponentUnionPluginOverride: v1alpha2.ComponentUnionPluginOverride{
+											Container: &v1alpha2.ContainerComponentPluginOverride{
+												ContainerPluginOverride: v1alpha2.ContainerPluginOverride{Image: "quay.io/devfile/runtime:dev"},
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+				{
+					Name: "custom",
+					ComponentUnion: v1alpha2.ComponentUnion{
+						Custom: &v1alpha2.CustomComponent{
+							ComponentClass:   "{{name}}",
+							EmbeddedResource: runtime.RawExtension{Raw: []byte(`{"tag":"{{tag}}"}`)},
+						},
+					},
+				},
+			},
+		},
+		{
+			name:      "Nil value",
+			value:     nil,
+			wantValue: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateAndReplaceFields(variables, tt.value)
+			assert.Equal(t, tt.wantValue, tt.value, "The two values should be the same.")
+
+			if len(tt.wantInvalid) == 0 {
+				assert.NoError(t, err, "Expected error to be nil")
+			} else if assert.IsType(t, &InvalidKeysError{}, err, "Expected InvalidKeysError") {
+				assert.Equal(t, tt.wantInvalid, err.(*InvalidKeysError).Keys, "the invalid keys should be the same")
+			}
+		})
+	}
+}
+
+func TestValidateAndReplaceFieldsKeyCollisions(t *testing.T) {
+
+	variables := map[string]string{
+		"name":  "runtime",
+		"other": "runtime",
+		"tag":   "dev",
+	}
+
+	tests := []struct {
+		name           string
+		value          interface{}
+		wantValue      interface{}
+		wantCollisions []*KeyCollisionError
+	}{
+		{
+			name: "Replaced keys not colliding",
+			value: &v1alpha2.GitLikeProjectSource{
+				Remotes: map[string]string{"{{name}}": "https://github.com/{{tag}}", "{{tag}}": "https://github.com/tag"},
+			},
+			wantValue: &v1alpha2.GitLikeProjectSource{
+				Remotes: map[string]string{"runtime": "https://github.com/dev", "dev": "https://github.com/tag"},
+			},
+		},
+		{
+			name: "Replaced key colliding with a key of the map",
+			value: &v1alpha2.GitLikeProjectSource{
+				Remotes: map[string]string{"{{name}}": "https://github.com/{{tag}}", "runtime": "https://github.com/runtime"},
+			},
+			wantValue: &v1alpha2.GitLikeProjectSource{
+				Remotes: map[string]string{"{{name}}": "https://github.com/dev", "runtime": "https://github.com/runtime"},
+			},
+			wantCollisions: []*KeyCollisionError{{Key: "{{name}}", ReplacedKey: "runtime"}},
+		},
+		{
+			name: "Replaced keys colliding with each other",
+			value: &v1alpha2.Annotation{
+				Service: map[string]string{"{{name}}": "a", "{{other}}": "b", "{{tag}}": "c"},
+			},
+			wantValue: &v1alpha2.Annotation{
+				Service: map[string]string{"{{name}}": "a", "{{other}}": "b", "dev": "c"},
+			},
+			wantCollisions: []*KeyCollisionError{{Key: "{{name}}", ReplacedKey: "runtime"}, {Key: "{{other}}", ReplacedKey: "runtime"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			collisions, err := validateAndReplaceFieldsWithKeyCollisions(variables, tt.value)
+			assert.NoError(t, err, "Expected error to be nil")
+			assert.Equal(t, tt.wantValue, tt.value, "The two values should be the same.")
+			assert.Equal(t, tt.wantCollisions, collisions, "The key collisions should be the same.")
+		})
+	}
+}
@@ -0,0 +1,99 @@
+//
+//
+// Copyright Red Hat
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validation
+
+import (
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/devfile/api/v2/pkg/apis/workspaces/v1alpha2"
+)
+
+// defaultSourceMapping is the path the project sources are mounted to when the container does not set sourceMapping
+const defaultSourceMapping = "/projects"
+
+// validateVolumeMountPaths checks if the volume mounts of the container component
+//  1. have an absolute and normalized path, ie; without empty, . or .. elements. A trailing slash is allowed
+//  2. do not resolve to the same path, a volume mount without path is mounted to /<name>
+//  3. do not collide with the sourceMapping of the container when the project sources are mounted
+func validateVolumeMountPaths(component v1alpha2.Component) (result ValidationResult) {
+	containerPath := componentTypePath(component)
+
+	sourceMapping := component.Container.SourceMapping
+	if sourceMapping == "" {
+		sourceMapping = defaultSourceMapping
+	}
+
+	processedPaths := make(map[string]string)
+	for _, volumeMount := range component.Container.VolumeMounts {
+		volumeMountPath := fmt.Sprintf("%s/volumeMounts[name=%s]", containerPath, volumeMount.Name)
+
+		mountPath := volumeMount.Path
+		if mountPath == "" {
+			mountPath = "/" + volumeMount.Name
+		} else if !path.IsAbs(mountPath) {
+			invalidPathErr := &InvalidVolumeMountPathError{volumeName: volumeMount.Name, componentName: component.Name, path: mountPath, reason: "the path must be absolute"}
+			result.addError(newFinding(RuleVolumeMountPath, invalidPathErr, VolumeMountElement, volumeMount.Name, volumeMountPath+"/path", component.Attributes))
+		} else if path.Clean(mountPath) != strings.TrimSuffix(mountPath, "/") && mountPath != "/" {
+			invalidPathErr := &InvalidVolumeMountPathError{volumeName: volumeMount.Name, componentName: component.Name, path: mountPath,
+				reason: fmt.Sprintf("the path must be normalized as %s", path.Clean(mountPath))}
+			result.addError(newFinding(RuleVolumeMountPath, invalidPathErr, VolumeMountElement, volumeMount.Name, volumeMountPath+"/path", component.Attributes))
+		}
+		resolvedPath := path.Clean("/" + mountPath)
+
+		if processedVolumeName, ok := processedPaths[resolvedPath]; ok {
+			duplicatePathErr := &DuplicateVolumeMountPathError{componentName: component.Name, path: resolvedPath, volumeNames: []string{processedVolumeName, volumeMount.Name}}
+			result.addError(newFinding(RuleVolumeMountDuplicatePath, duplicatePathErr, VolumeMountElement, volumeMount.Name, volumeMountPath, component.Attributes))
+		} else {
+			processedPaths[resolvedPath] = volumeMount.Name
+		}
+
+		if component.Container.GetMountSources() && resolvedPath == path.Clean(sourceMapping) {
+			collisionErr := &VolumeMountSourceMappingError{volumeName: volumeMount.Name, componentName: component.Name, path: resolvedPath}
+			result.addError(newFinding(RuleVolumeMountSourceMapping, collisionErr, VolumeMountElement, volumeMount.Name, volumeMountPath, component.Attributes))
+		}
+	}
+
+	return result
+}
+
+// validateVolume checks the volume component, an ephemeral volume with a size is reported as a warning
+// since the size is ignored
+func validateVolume(component v1alpha2.Component) (result ValidationResult) {
+	if component.Volume.Ephemeral != nil && *component.Volume.Ephemeral && component.Volume.Size != "" {
+		result.addWarning(newFinding(RuleVolumeEphemeralSize, &EphemeralVolumeSizeWarning{name: component.Name},
+			ComponentElement, component.Name, fmt.Sprintf("%s/size", componentTypePath(component)), component.Attributes))
+	}
+	return result
+}
+
+// validateUnusedVolumes reports the volume components that no container mounts as warnings
+func validateUnusedVolumes(components []v1alpha2.Component, volumeMounts []volumeMountReference) (result ValidationResult) {
+	mountedVolumes := make(map[string]bool)
+	for _, reference := range volumeMounts {
+		mountedVolumes[reference.volumeMount.Name] = true
+	}
+
+	for _, component := range components {
+		if component.Volume != nil && !mountedVolumes[component.Name] {
+			result.addWarning(newFinding(RuleUnusedVolume, &UnusedVolumeWarning{name: component.Name},
+				ComponentElement, component.Name, componentPath(component), component.Attributes))
+		}
+	}
+	return result
+}
@@ -0,0 +1,135 @@
+//
+//
+// Copyright Red Hat
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package validation
+
+import (
+	"testing"
+
+	"github.com/devfile/api/v2/pkg/apis/workspaces/v1alpha2"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateVolumes(t *testing.T) {
+
+	ephemeral := true
+	mountSources := false
+
+	generateEphemeralVolumeComponent := func(name, size string) v1alpha2.Component {
+		component := generateDummyVolumeComponent(name, size)
+		component.Volume.Ephemeral = &ephemeral
+		return component
+	}
+
+	relativePathErr := "the path data of volume mount myvol in container component container1 is invalid: the path must be absolute"
+	nonNormalizedPathErr := "the path /data/../cache of volume mount myvol in container component container1 is invalid: the path must be normalized as /cache"
+	duplicatePathErr := "volume mounts myvol and myvol2 in container component container1 are mounted to the same path /data"
+	defaultPathDuplicateErr := "volume mounts myvol and myvol2 in container component container1 are mounted to the same path /myvol"
+	sourceMappingErr := "volume mount myvol in container component container1 is mounted to the path /projects which collides with the sourceMapping of the container"
+	customSourceMappingErr := "volume mount myvol in container component container1 is mounted to the path /src which collides with the sourceMapping of the container"
+	ephemeralSizeWarning := "volume component myvol is ephemeral, its size is ignored"
+	unusedVolumeWarning := "volume component myvol2 is not mounted by any container component"
+
+	tests := []struct {
+		name          string
+		volumeMounts  []v1alpha2.VolumeMount
+		sourceMapping string
+		mountSources  *bool
+		volumes       []v1alpha2.Component
+		wantErr       []string
+		wantWarning   []string
+	}{
+		{
+			name:         "Valid volume mounts",
+			volumeMounts: []v1alpha2.VolumeMount{{Name: "myvol", Path: "/data/"}, {Name: "myvol2"}},
+			volumes:      []v1alpha2.Component{generateDummyVolumeComponent("myvol", "1Gi"), generateEphemeralVolumeComponent("myvol2", "")},
+		},
+		{
+			name:         "Valid volume mount under the sourceMapping",
+			volumeMounts: []v1alpha2.VolumeMount{{Name: "myvol", Path: "/projects/node_modules"}},
+			volumes:      []v1alpha2.Component{generateDummyVolumeComponent("myvol", "")},
+		},
+		{
+			name:         "Relative mount path",
+			volumeMounts: []v1alpha2.VolumeMount{{Name: "myvol", Path: "data"}},
+			volumes:      []v1alpha2.Component{generateDummyVolumeComponent("myvol", "")},
+			wantErr:      []string{relativePathErr},
+		},
+		{
+			name:         "Non-normalized mount path",
+			volumeMounts: []v1alpha2.VolumeMount{{Name: "myvol", Path: "/data/../cache"}},
+			volumes:      []v1alpha2.Component{generateDummyVolumeComponent("myvol", "")},
+			wantErr:      []string{nonNormalizedPathErr},
+		},
+		{
+			name:         "Mount paths resolving to the same path",
+			volumeMounts: []v1alpha2.VolumeMount{{Name: "myvol", Path: "/data"}, {Name: "myvol2", Path: "/data/"}},
+			volumes:      []v1alpha2.Component{generateDummyVolumeComponent("myvol", ""), generateDummyVolumeComponent("myvol2", "")},
+			wantErr:      []string{duplicatePathErr},
+		},
+		{
+			name:         "Mount path resolving to the default path of another volume mount",
+			volumeMounts: []v1alpha2.VolumeMount{{Name: "myvol"}, {Name: "myvol2", Path: "/myvol"}},
+			volumes:      []v1alpha2.Component{generateDummyVolumeComponent("myvol", ""), generateDummyVolumeComponent("myvol2", "")},
+			wantErr:      []string{defaultPathDuplicateErr},
+		},
+		{
+			name:         "Mount path colliding with the default sourceMapping",
+			volumeMounts: []v1alpha2.VolumeMount{{Name: "myvol", Path: "/projects"}},
+			volumes:      []v1alpha2.Component{generateDummyVolumeComponent("myvol", "")},
+			wantErr:      []string{sourceMappingErr},
+		},
+		{
+			name:          "Mount path colliding with a custom sourceMapping",
+			volumeMounts:  []v1alpha2.VolumeMount{{Name: "myvol", Path: "/src"}},
+			sourceMapping: "/src",
+			volumes:       []v1alpha2.Component{generateDummyVolumeComponent("myvol", "")},
+			wantErr:       []string{customSourceMappingErr},
+		},
+		{
+			name:         "Mount path on the sourceMapping when the sources are not mounted",
+			volumeMounts: []v1alpha2.VolumeMount{{Name: "myvol", Path: "/projects"}},
+			mountSources: &mountSources,
+			volumes:      []v1alpha2.Component{generateDummyVolumeComponent("myvol", "")},
+		},
+		{
+			name:         "Ephemeral volume with a size and unused volume",
+			volumeMounts: []v1alpha2.VolumeMount{{Name: "myvol"}},
+			volumes:      []v1alpha2.Component{generateEphemeralVolumeComponent("myvol", "1Gi"), generateDummyVolumeComponent("myvol2", "")},
+			wantWarning:  []string{ephemeralSizeWarning, unusedVolumeWarning},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			container := generateDummyContainerComponent("container1", tt.volumeMounts, nil, nil, v1alpha2.Annotation{}, false)
+			container.Container.SourceMapping = tt.sourceMapping
+			container.Container.MountSources = tt.mountSources
+
+			result := ValidateComponents(append([]v1alpha2.Component{container}, tt.volumes...))
+
+			if assert.Equal(t, len(tt.wantErr), len(result.Errors), "Error list length should match") {
+				for i := 0; i < len(result.Errors); i++ {
+					assert.Regexp(t, tt.wantErr[i], result.Errors[i].Error(), "Error message should match")
+				}
+			}
+			if assert.Equal(t, len(tt.wantWarning), len(result.Warnings), "Warning list length should match") {
+				for i := 0; i < len(result.Warnings); i++ {
+					assert.Regexp(t, tt.wantWarning[i], result.Warnings[i].Error(), "Warning message should match")
+				}
+			}
+		})
+	}
+}